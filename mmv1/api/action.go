@@ -0,0 +1,196 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+var actionNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// A custom method of the resource, such as `:restart`, generated as a
+// Terraform action. Actions are implemented with the plugin framework. The
+// fields of the resource's self_link are the action's arguments along with
+// the properties sent in the body of the call.
+//
+// If the method returns a long-running operation, list the action's name in
+// the resource's `async.actions` and the action waits for the operation the
+// same way the resource's CRUD functions do.
+type Action struct {
+	// The name of the action in snake_case, such as `restart`. The action's
+	// Terraform type is the resource's name followed by it.
+	Name string
+
+	// A description of the action, used in its documentation.
+	Description string
+
+	// [Optional] The HTTP verb of the call. Defaults to POST.
+	Verb string `yaml:"verb,omitempty"`
+
+	// [Optional] The suffix appended to the resource's self_link to call the
+	// method. Defaults to `:` followed by the action's name in camelCase,
+	// such as `:restart`.
+	UrlSuffix string `yaml:"url_suffix,omitempty"`
+
+	// [Optional] The properties sent in the body of the call. They are
+	// declared the same way as the resource's properties.
+	Properties []*Type `yaml:"properties,omitempty"`
+
+	// [Optional] The number of minutes the action waits for its operation.
+	// Defaults to the resource's update timeout.
+	TimeoutMinutes int `yaml:"timeout_minutes,omitempty"`
+
+	ResourceMetadata *Resource `yaml:"-"`
+}
+
+func (a *Action) SetDefault(r *Resource) {
+	a.ResourceMetadata = r
+	if a.Verb == "" {
+		a.Verb = "POST"
+	}
+	if a.UrlSuffix == "" {
+		a.UrlSuffix = fmt.Sprintf(":%s", google.Camelize(a.Name, "lower"))
+	}
+	if a.TimeoutMinutes == 0 {
+		a.TimeoutMinutes = r.GetTimeouts().UpdateMinutes
+	}
+	for _, p := range a.Properties {
+		// Keeps the expanders of the action apart from the resource's, which
+		// may have properties of the same name.
+		p.Prefix = a.goName()
+		p.SetDefault(r)
+	}
+}
+
+func (a Action) goName() string {
+	return fmt.Sprintf("%s%sAction", a.ResourceMetadata.ResourceName(), google.Camelize(a.Name, "upper"))
+}
+
+// Returns the name of the function returning the action
+func (a Action) FunctionName() string {
+	return fmt.Sprintf("New%s", a.goName())
+}
+
+// Returns the name of the struct implementing the action
+func (a Action) StructName() string {
+	return google.Camelize(a.goName(), "lower")
+}
+
+// Returns the Terraform type name of the action, such as
+// `google_compute_instance_restart`
+func (a Action) TerraformName() string {
+	return fmt.Sprintf("%s_%s", a.ResourceMetadata.TerraformName(), a.Name)
+}
+
+// Returns the url the action calls, relative to the product's base_url
+func (a Action) Uri() string {
+	return a.ResourceMetadata.SelfLinkUri() + a.UrlSuffix
+}
+
+// Returns the properties sent in the body of the call that are part of the
+// version being generated
+func (a Action) UserProperties() []*Type {
+	return google.Reject(a.Properties, func(p *Type) bool {
+		return p.Exclude
+	})
+}
+
+// Returns true if the action waits for the operation returned by its call
+func (a Action) WaitsForOperation() bool {
+	async := a.ResourceMetadata.GetAsync()
+	return async != nil && async.IsA("OpAsync") && async.Allow(a.Name)
+}
+
+// Returns the fields of the action's url that users must set
+func (a Action) RequiredUrlFields() []string {
+	return a.ResourceMetadata.requiredUrlFields(a.Uri())
+}
+
+// Returns the fields of the action's url that default to the provider
+// configuration or to their default_value
+func (a Action) OptionalUrlFields() []string {
+	return a.ResourceMetadata.optionalUrlFields(a.Uri())
+}
+
+// Returns all fields of the action's url that users may set
+func (a Action) UrlFields() []string {
+	return google.Concat(a.RequiredUrlFields(), a.OptionalUrlFields())
+}
+
+// Returns the required properties sent with the call, in the order they're
+// documented
+func (a Action) RequiredProperties() []*Type {
+	return google.Select(a.ResourceMetadata.OrderProperties(a.UserProperties()), func(p *Type) bool {
+		return p.Required
+	})
+}
+
+// Returns the optional properties sent with the call, in the order they're
+// documented
+func (a Action) OptionalProperties() []*Type {
+	return google.Reject(a.ResourceMetadata.OrderProperties(a.UserProperties()), func(p *Type) bool {
+		return p.Required
+	})
+}
+
+// Returns the description of a property sent with the call, on a single line
+// for the action's documentation
+func (a Action) PropertyDescription(p *Type) string {
+	return strings.Join(strings.Fields(p.Description), " ")
+}
+
+func (r Resource) validateActions() ValidationErrors {
+	var errs ValidationErrors
+	names := map[string]bool{}
+	for i, a := range r.Actions {
+		path := fmt.Sprintf("actions.%d", i)
+		if a.Name == "" {
+			errs.Add(path, "Missing `name` for action")
+		} else if !actionNameRegex.MatchString(a.Name) {
+			errs.Add(joinYamlPath(path, "name"), "Action name `%s` must be in snake_case", a.Name)
+		} else if names[a.Name] {
+			errs.Add(joinYamlPath(path, "name"), "Action name `%s` is used by more than one action", a.Name)
+		}
+		names[a.Name] = true
+
+		allowed := []string{"POST", "PUT", "PATCH"}
+		if !slices.Contains(allowed, a.Verb) {
+			errs.Add(joinYamlPath(path, "verb"), "Value on `verb` should be one of %#v", allowed)
+		}
+		if r.Exclude {
+			errs.Add(path, "Cannot generate action `%s` for resource %s, as it is excluded; read-only resources should set exclude_resource instead", a.Name, r.Name)
+		}
+		for _, f := range a.UrlFields() {
+			if !r.hasSchemaField(f) && !(f == "project" && r.HasProject()) {
+				errs.Add(path, "Field %s of the URL of action `%s` isn't part of the schema of resource %s", f, a.Name, r.Name)
+			}
+		}
+
+		for _, p := range a.Properties {
+			errs.Append(p.Validate(r.Name))
+			if p.Output {
+				errs.Add(p.YamlPath(), "Property %s of action `%s` can't be output, as actions have no state", p.Name, a.Name)
+			}
+			if slices.Contains(a.UrlFields(), google.Underscore(p.Name)) {
+				errs.Add(p.YamlPath(), "Property %s of action `%s` has the same name as a field of its URL", p.Name, a.Name)
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResourceActions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		async       *Async
+		actions     []*Action
+		uri         string
+		verb        string
+		name        string
+		function    string
+		waits       bool
+		required    []string
+		optional    []string
+		errorPaths  []string
+	}{
+		{
+			description: "call the custom method of the self link by default",
+			actions: []*Action{
+				{Name: "start_all"},
+			},
+			uri:      "projects/{{project}}/widgets/{{name}}:startAll",
+			verb:     "POST",
+			name:     "google_example_widget_start_all",
+			function: "NewExampleWidgetStartAllAction",
+			required: []string{"name"},
+			optional: []string{"project"},
+		},
+		{
+			description: "wait for operations of actions listed in async",
+			async:       &Async{Type: "OpAsync", Actions: []string{"create", "delete", "update", "restart"}},
+			actions: []*Action{
+				{Name: "restart", Verb: "PATCH", UrlSuffix: "/restart", Properties: []*Type{
+					{Name: "nodeIds", Type: "Array", ItemType: &Type{Type: "String"}},
+				}},
+			},
+			uri:      "projects/{{project}}/widgets/{{name}}/restart",
+			verb:     "PATCH",
+			name:     "google_example_widget_restart",
+			function: "NewExampleWidgetRestartAction",
+			waits:    true,
+			required: []string{"name"},
+			optional: []string{"project"},
+		},
+		{
+			description: "don't wait for operations of actions missing from async",
+			async:       &Async{Type: "OpAsync", Actions: []string{"create", "delete", "update"}},
+			actions: []*Action{
+				{Name: "restart"},
+			},
+			uri:      "projects/{{project}}/widgets/{{name}}:restart",
+			verb:     "POST",
+			name:     "google_example_widget_restart",
+			function: "NewExampleWidgetRestartAction",
+			required: []string{"name"},
+			optional: []string{"project"},
+		},
+		{
+			description: "invalid actions",
+			actions: []*Action{
+				{Name: "restart", Verb: "GET", Properties: []*Type{
+					{Name: "state", Type: "String", Output: true},
+					{Name: "name", Type: "String"},
+				}},
+				{Name: "restart"},
+				{Name: "Stop"},
+			},
+			uri:        "projects/{{project}}/widgets/{{name}}:restart",
+			verb:       "GET",
+			name:       "google_example_widget_restart",
+			function:   "NewExampleWidgetRestartAction",
+			required:   []string{"name"},
+			optional:   []string{"project"},
+			errorPaths: []string{"actions.0.verb", "actions.0.properties.state", "actions.0.properties.name", "actions.1.name", "actions.2.name"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := Resource{
+				Name:            "Widget",
+				BaseUrl:         "projects/{{project}}/widgets",
+				Async:           tc.async,
+				Actions:         tc.actions,
+				ProductMetadata: &Product{Name: "Example"},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			}
+			for _, p := range r.Properties {
+				p.ResourceMetadata = &r
+			}
+			for _, a := range r.Actions {
+				a.SetDefault(&r)
+			}
+
+			a := r.Actions[0]
+			if got, want := a.Uri(), tc.uri; got != want {
+				t.Errorf("expected uri %q to be %q", got, want)
+			}
+			if got, want := a.Verb, tc.verb; got != want {
+				t.Errorf("expected verb %q to be %q", got, want)
+			}
+			if got, want := a.TerraformName(), tc.name; got != want {
+				t.Errorf("expected terraform name %q to be %q", got, want)
+			}
+			if got, want := a.FunctionName(), tc.function; got != want {
+				t.Errorf("expected function name %q to be %q", got, want)
+			}
+			if got, want := a.WaitsForOperation(), tc.waits; got != want {
+				t.Errorf("expected waiting for operations %v to be %v", got, want)
+			}
+			if got, want := a.RequiredUrlFields(), tc.required; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected required fields %v to be %v", got, want)
+			}
+			if got, want := a.OptionalUrlFields(), tc.optional; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected optional fields %v to be %v", got, want)
+			}
+			for _, p := range a.Properties {
+				if got, want := p.GetPrefix(), strings.TrimPrefix(tc.function, "New"); got != want {
+					t.Errorf("expected prefix of %s %q to be %q", p.Name, got, want)
+				}
+			}
+
+			var paths []string
+			for _, err := range r.validateActions() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
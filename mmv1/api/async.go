@@ -14,8 +14,9 @@
 package api
 
 import (
-	"log"
+	"fmt"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/slices"
 )
@@ -81,6 +82,55 @@ type OpAsyncOperation struct {
 
 	// Use this if the resource includes the full operation url.
 	FullUrl string `yaml:"full_url,omitempty"`
+
+	// The HTTP verb the operation is polled with. Defaults to GET.
+	Verb string `yaml:"verb,omitempty"`
+
+	// The fields below describe operations that don't have the shape of a
+	// google.longrunning.Operation. Paths are dot-separated paths of fields in
+	// the operation, such as `metadata.state`.
+
+	// The path of the operation's name, which replaces {{op_id}} in the
+	// operation URL. Defaults to `name`.
+	NamePath string `yaml:"name_path,omitempty"`
+
+	// The path of the flag set when the operation is done. Defaults to `done`.
+	DonePath string `yaml:"done_path,omitempty"`
+
+	// The path of the operation's status, for operations without a done flag.
+	// The operation is polled while its status is one of pending_statuses,
+	// and is done once it's one of target_statuses.
+	StatusPath      string   `yaml:"status_path,omitempty"`
+	PendingStatuses []string `yaml:"pending_statuses,omitempty"`
+	TargetStatuses  []string `yaml:"target_statuses,omitempty"`
+
+	// The path of the error of a failed operation. Defaults to `error`.
+	ErrorPath string `yaml:"error_path,omitempty"`
+
+	// The path of the resource in a finished operation, used with
+	// `resource_inside_response`. Defaults to `response`.
+	ResponsePath string `yaml:"response_path,omitempty"`
+
+	// The path of the URL of the operation, for operations that link to
+	// themselves. The operation is polled at this URL, such as for compute
+	// operations that can be zonal, regional or global.
+	SelfLinkPath string `yaml:"self_link_path,omitempty"`
+
+	// The name of a function in the product's package that builds the error
+	// of a failed operation from the whole operation, with the signature
+	// `func(op map[string]interface{}) error`. It's usually defined in the
+	// resource's custom_code.constants.
+	ErrorFormatter string `yaml:"error_formatter,omitempty"`
+
+	// How long to wait between polls of the operation. Defaults to the
+	// provider's poll interval.
+	Polling *Polling `yaml:"polling,omitempty"`
+}
+
+// Returns true if the operation is described by paths and statuses, rather
+// than having the shape of a google.longrunning.Operation.
+func (o OpAsyncOperation) IsDescribed() bool {
+	return o.NamePath != "" || o.DonePath != "" || o.StatusPath != "" || o.ErrorPath != "" || o.ResponsePath != "" || o.SelfLinkPath != "" || o.ErrorFormatter != ""
 }
 
 // Represents the results of an Operation request
@@ -107,6 +157,91 @@ type PollAsync struct {
 	// Number of times the desired state has to occur continuously
 	// during polling before returning a success
 	TargetOccurrences int `yaml:"target_occurrences,omitempty"`
+
+	// How long to wait between polls of the resource. Defaults to backing
+	// off from 500ms to 10s.
+	Polling *Polling `yaml:"polling,omitempty"`
+}
+
+// Returns the transport_tpg.PollingStrategy the resource is polled with.
+func (a PollAsync) PollingStrategyLiteral() string {
+	if a.Polling == nil {
+		return "transport_tpg.DefaultPollingStrategy"
+	}
+	return a.Polling.StrategyLiteral()
+}
+
+// Describes how long to wait between polls, as a backoff from
+// initial_interval up to max_interval. Every interval is multiplied by the
+// provider's poll_interval_scale.
+type Polling struct {
+	// The wait before the second poll, as a duration such as `5s`.
+	InitialInterval string `yaml:"initial_interval"`
+
+	// The longest wait between polls, as a duration. Defaults to no limit.
+	MaxInterval string `yaml:"max_interval,omitempty"`
+
+	// The factor each wait is multiplied by to get the next one. Defaults
+	// to 1, polling at a fixed interval.
+	Multiplier float64 `yaml:"multiplier,omitempty"`
+
+	// The fraction of each wait, between 0 and 1, by which it's lengthened
+	// or shortened at random.
+	Jitter float64 `yaml:"jitter,omitempty"`
+}
+
+// Returns the transport_tpg.PollingStrategy literal the polling is generated
+// as. It assumes the polling is valid.
+func (p Polling) StrategyLiteral() string {
+	fields := []string{fmt.Sprintf("InitialInterval: %s", durationLiteral(p.InitialInterval))}
+	if p.MaxInterval != "" {
+		fields = append(fields, fmt.Sprintf("MaxInterval: %s", durationLiteral(p.MaxInterval)))
+	}
+	if p.Multiplier != 0 {
+		fields = append(fields, fmt.Sprintf("Multiplier: %v", p.Multiplier))
+	}
+	if p.Jitter != 0 {
+		fields = append(fields, fmt.Sprintf("Jitter: %v", p.Jitter))
+	}
+	return fmt.Sprintf("transport_tpg.PollingStrategy{%s}", strings.Join(fields, ", "))
+}
+
+// Returns Go code for a duration, in the largest unit that represents it
+// exactly.
+func durationLiteral(s string) string {
+	d, _ := time.ParseDuration(s)
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{{time.Hour, "Hour"}, {time.Minute, "Minute"}, {time.Second, "Second"}, {time.Millisecond, "Millisecond"}} {
+		if d%unit.d == 0 {
+			return fmt.Sprintf("%d * time.%s", d/unit.d, unit.name)
+		}
+	}
+	return fmt.Sprintf("%d", d)
+}
+
+// Validates the polling. Paths of the returned errors are relative to the
+// `polling` key.
+func (p Polling) validate() ValidationErrors {
+	var errs ValidationErrors
+	initial, err := time.ParseDuration(p.InitialInterval)
+	if err != nil || initial <= 0 {
+		errs.Add("initial_interval", "`initial_interval` must be a positive duration such as `5s`, got %q.", p.InitialInterval)
+	}
+	if p.MaxInterval != "" {
+		maxInterval, err := time.ParseDuration(p.MaxInterval)
+		if err != nil || maxInterval < initial {
+			errs.Add("max_interval", "`max_interval` must be a duration at least as long as `initial_interval`, got %q.", p.MaxInterval)
+		}
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		errs.Add("multiplier", "`multiplier` must be at least 1, got %v.", p.Multiplier)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		errs.Add("jitter", "`jitter` must be between 0 and 1, got %v.", p.Jitter)
+	}
+	return errs
 }
 
 func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
@@ -129,14 +264,45 @@ func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (a *Async) Validate() {
+// Validates the async block. Paths of the returned errors are relative to
+// the `async` key.
+func (a *Async) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
-			log.Fatalf("Missing `Operation` for OpAsync")
+			errs.Add("operation", "Missing `Operation` for OpAsync")
 		} else {
 			if a.Operation.BaseUrl != "" && a.Operation.FullUrl != "" {
-				log.Fatalf("`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+				errs.Add("operation", "`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+			}
+			errs.Append(a.Operation.validateDescriptor().WithPathPrefix("operation"))
+			if a.Operation.Polling != nil {
+				errs.Append(a.Operation.Polling.validate().WithPathPrefix("operation.polling"))
 			}
 		}
 	}
+	if a.Type == "PollAsync" && a.PollAsync.Polling != nil {
+		errs.Append(a.PollAsync.Polling.validate().WithPathPrefix("polling"))
+	}
+	return errs
+}
+
+// Validates the fields describing an operation. Paths of the returned errors
+// are relative to the `operation` key.
+func (o OpAsyncOperation) validateDescriptor() ValidationErrors {
+	var errs ValidationErrors
+	if o.StatusPath != "" {
+		if o.DonePath != "" {
+			errs.Add("done_path", "`done_path` and `status_path` cannot be set at the same time in OpAsync operation.")
+		}
+		if len(o.TargetStatuses) == 0 {
+			errs.Add("target_statuses", "`target_statuses` must be set with `status_path` in OpAsync operation.")
+		}
+	} else if len(o.PendingStatuses) > 0 || len(o.TargetStatuses) > 0 {
+		errs.Add("status_path", "`pending_statuses` and `target_statuses` can only be set with `status_path` in OpAsync operation.")
+	}
+	if o.SelfLinkPath != "" && (o.BaseUrl != "" || o.FullUrl != "") {
+		errs.Add("self_link_path", "`self_link_path` cannot be set with `base_url` or `full_url` in OpAsync operation.")
+	}
+	return errs
 }
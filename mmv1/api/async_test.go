@@ -0,0 +1,149 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAsyncValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		operation   OpAsyncOperation
+		errorPaths  []string
+	}{
+		{
+			description: "common operation",
+			operation:   OpAsyncOperation{BaseUrl: "{{op_id}}"},
+		},
+		{
+			description: "described by status",
+			operation: OpAsyncOperation{
+				SelfLinkPath:    "selfLink",
+				StatusPath:      "status",
+				PendingStatuses: []string{"PENDING", "RUNNING"},
+				TargetStatuses:  []string{"DONE"},
+			},
+		},
+		{
+			description: "status without target statuses",
+			operation:   OpAsyncOperation{BaseUrl: "{{op_id}}", StatusPath: "status", DonePath: "done"},
+			errorPaths:  []string{"operation.done_path", "operation.target_statuses"},
+		},
+		{
+			description: "statuses without a status path",
+			operation:   OpAsyncOperation{BaseUrl: "{{op_id}}", TargetStatuses: []string{"DONE"}},
+			errorPaths:  []string{"operation.status_path"},
+		},
+		{
+			description: "self link and base url",
+			operation:   OpAsyncOperation{BaseUrl: "{{op_id}}", SelfLinkPath: "selfLink"},
+			errorPaths:  []string{"operation.self_link_path"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			a := Async{Type: "OpAsync", Operation: &Operation{OpAsyncOperation: tc.operation}}
+			var paths []string
+			for _, err := range a.Validate() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPollingValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		async       Async
+		errorPaths  []string
+	}{
+		{
+			description: "operation polling",
+			async: Async{Type: "OpAsync", Operation: &Operation{OpAsyncOperation: OpAsyncOperation{
+				Polling: &Polling{InitialInterval: "5s", MaxInterval: "1m", Multiplier: 2, Jitter: 0.1},
+			}}},
+		},
+		{
+			description: "resource polling",
+			async:       Async{Type: "PollAsync", PollAsync: PollAsync{Polling: &Polling{InitialInterval: "500ms"}}},
+		},
+		{
+			description: "invalid operation polling",
+			async: Async{Type: "OpAsync", Operation: &Operation{OpAsyncOperation: OpAsyncOperation{
+				Polling: &Polling{InitialInterval: "5", MaxInterval: "1m", Multiplier: 0.5, Jitter: 2},
+			}}},
+			errorPaths: []string{"operation.polling.initial_interval", "operation.polling.multiplier", "operation.polling.jitter"},
+		},
+		{
+			description: "max interval shorter than initial interval",
+			async:       Async{Type: "PollAsync", PollAsync: PollAsync{Polling: &Polling{InitialInterval: "1m", MaxInterval: "5s"}}},
+			errorPaths:  []string{"polling.max_interval"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var paths []string
+			for _, err := range tc.async.Validate() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPollingStrategyLiteral(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		polling     Polling
+		expected    string
+	}{
+		{
+			description: "fixed interval",
+			polling:     Polling{InitialInterval: "30s"},
+			expected:    "transport_tpg.PollingStrategy{InitialInterval: 30 * time.Second}",
+		},
+		{
+			description: "backoff",
+			polling:     Polling{InitialInterval: "1.5s", MaxInterval: "2m", Multiplier: 1.5, Jitter: 0.2},
+			expected:    "transport_tpg.PollingStrategy{InitialInterval: 1500 * time.Millisecond, MaxInterval: 2 * time.Minute, Multiplier: 1.5, Jitter: 0.2}",
+		},
+		{
+			description: "hours",
+			polling:     Polling{InitialInterval: "60m"},
+			expected:    "transport_tpg.PollingStrategy{InitialInterval: 1 * time.Hour}",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.polling.StrategyLiteral(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -15,16 +15,22 @@ package api
 
 import (
 	"bytes"
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}, overrideDir string) {
+// Reads the YAML file at yamlPath into obj. Problems reading or decoding the
+// file are returned rather than stopping the generator, so that they can be
+// reported together with the validation errors of other files.
+func Compile(yamlPath string, obj interface{}, overrideDir string) ValidationErrors {
 	objYaml, err := os.ReadFile(yamlPath)
 	if err != nil {
-		log.Fatalf("Cannot open the file: %v", objYaml)
+		return ValidationErrors{{File: yamlPath, Message: fmt.Sprintf("cannot open the file: %v", err)}}
 	}
 
 	if overrideDir != "" {
@@ -32,5 +38,34 @@ func Compile(yamlPath string, obj interface{}, overrideDir string) {
 	}
 
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	if err := yamlValidator.Parse(objYaml, obj, yamlPath); err != nil {
+		return yamlFileErrors(yamlPath, err)
+	}
+	return nil
+}
+
+// Reads the YAML file at yamlPath into obj, merged with its override under
+// overrideDir if there is one, the same way the generator reads products and
+// resources. The files that were read are returned.
+func compileWithOverrides[T any](yamlPath string, obj *T, overrideDir string) ([]string, ValidationErrors) {
+	var overridePath string
+	if overrideDir != "" {
+		overridePath = filepath.Join(overrideDir, yamlPath)
+		if _, err := os.Stat(overridePath); errors.Is(err, os.ErrNotExist) {
+			overridePath = ""
+		}
+	}
+
+	if overridePath == "" {
+		return []string{yamlPath}, Compile(yamlPath, obj, overrideDir)
+	}
+	if _, err := os.Stat(yamlPath); errors.Is(err, os.ErrNotExist) {
+		return []string{overridePath}, Compile(overridePath, obj, overrideDir)
+	}
+
+	errs := Compile(yamlPath, obj, overrideDir)
+	override := new(T)
+	errs.Append(Compile(overridePath, override, overrideDir))
+	Merge(reflect.ValueOf(obj), reflect.ValueOf(*override))
+	return []string{yamlPath, overridePath}, errs
 }
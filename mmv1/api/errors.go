@@ -0,0 +1,309 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// A single problem found while compiling or validating a product or
+// resource definition.
+type ValidationError struct {
+	// The YAML file the problem was found in.
+	File string `json:"file,omitempty"`
+
+	// The position of the offending node within File. Zero when the
+	// position could not be determined.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// Dot notation path to the offending field within the YAML file, using
+	// the `name` of list entries and skipping the `properties`, `item_type`
+	// and `value_type` keys of nested fields.
+	// eg: properties.networkConfig.subnet
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+
+	// The ID of the lint rule that reported the problem, if any.
+	Rule string `json:"rule,omitempty"`
+}
+
+// Formats the problem as `file:line:column: path: message [rule]`, omitting
+// the parts that are unknown.
+func (e ValidationError) Error() string {
+	var parts []string
+	if e.File != "" {
+		location := []string{e.File}
+		if e.Line > 0 {
+			location = append(location, strconv.Itoa(e.Line))
+			if e.Column > 0 {
+				location = append(location, strconv.Itoa(e.Column))
+			}
+		}
+		parts = append(parts, strings.Join(location, ":"))
+	}
+	if e.Path != "" {
+		parts = append(parts, e.Path)
+	}
+	message := e.Message
+	if e.Rule != "" {
+		message = fmt.Sprintf("%s [%s]", message, e.Rule)
+	}
+	return strings.Join(append(parts, message), ": ")
+}
+
+// An aggregate of validation problems, so that every problem across all
+// products and resources can be reported at once instead of stopping the
+// generator at the first one.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	return errs.Text()
+}
+
+// Records a new problem at the given field path.
+func (errs *ValidationErrors) Add(path, format string, a ...any) {
+	*errs = append(*errs, &ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// Records all problems from other.
+func (errs *ValidationErrors) Append(other ValidationErrors) {
+	*errs = append(*errs, other...)
+}
+
+// Records plain errors, such as the ones returned by the validators in the
+// api/resource and api/product packages, at the given field path.
+func (errs *ValidationErrors) AppendErrors(path string, other []error) {
+	for _, err := range other {
+		errs.Add(path, "%s", err)
+	}
+}
+
+// Sets the file for all problems that don't have one yet.
+func (errs ValidationErrors) InFile(file string) ValidationErrors {
+	for _, e := range errs {
+		if e.File == "" {
+			e.File = file
+		}
+	}
+	return errs
+}
+
+// Prepends prefix to the path of all problems, for validators that report
+// paths relative to a nested block such as `async`.
+func (errs ValidationErrors) WithPathPrefix(prefix string) ValidationErrors {
+	for _, e := range errs {
+		if e.Path == "" {
+			e.Path = prefix
+		} else {
+			e.Path = fmt.Sprintf("%s.%s", prefix, e.Path)
+		}
+	}
+	return errs
+}
+
+// Looks up the line and column of every problem that has a file and a
+// path but no position yet. Files are parsed once each. If a path can't be
+// found in its file, the position of its closest ancestor is used.
+func (errs ValidationErrors) ResolvePositions() {
+	indexes := make(map[string]map[string]yaml.Node)
+	for _, e := range errs {
+		if e.File == "" || e.Line > 0 {
+			continue
+		}
+
+		index, ok := indexes[e.File]
+		if !ok {
+			index = yamlPositionIndex(e.File)
+			indexes[e.File] = index
+		}
+
+		path := e.Path
+		for {
+			if node, ok := index[path]; ok {
+				e.Line = node.Line
+				e.Column = node.Column
+				break
+			}
+			i := strings.LastIndex(path, ".")
+			if i == -1 {
+				break
+			}
+			path = path[:i]
+		}
+	}
+}
+
+// Sorts problems by file, position and path.
+func (errs ValidationErrors) Sort() {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Path < b.Path
+	})
+}
+
+// Returns a human-readable report, one problem per line.
+func (errs ValidationErrors) Text() string {
+	lines := make([]string, 0, len(errs))
+	for _, e := range errs {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns a machine-readable report of all problems.
+func (errs ValidationErrors) JSON() ([]byte, error) {
+	report := struct {
+		Count  int              `json:"count"`
+		Errors ValidationErrors `json:"errors"`
+	}{
+		Count:  len(errs),
+		Errors: errs,
+	}
+	if report.Errors == nil {
+		report.Errors = ValidationErrors{}
+	}
+	return json.MarshalIndent(report, "", "  ")
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`^\s*line (\d+): (.*)$`)
+
+// Converts an error returned while reading or unmarshalling a YAML file into
+// problems, splitting out the individual line-numbered errors reported by
+// the YAML decoder.
+func yamlFileErrors(file string, err error) ValidationErrors {
+	var errs ValidationErrors
+
+	var typeErr *yamlv2.TypeError
+	if errors.As(err, &typeErr) {
+		for _, msg := range typeErr.Errors {
+			errs = append(errs, yamlErrorLine(file, msg))
+		}
+		return errs
+	}
+
+	// Report the decoder's own message without the wrapping context
+	for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
+		err = unwrapped
+	}
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	return append(errs, yamlErrorLine(file, msg))
+}
+
+func yamlErrorLine(file, msg string) *ValidationError {
+	e := &ValidationError{File: file, Message: msg}
+	if m := yamlErrorLineRegexp.FindStringSubmatch(msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Message = m[2]
+	}
+	return e
+}
+
+// Builds a map of field paths, in the format described on
+// ValidationError.Path, to the YAML nodes they were declared at.
+func yamlPositionIndex(file string) map[string]yaml.Node {
+	index := make(map[string]yaml.Node)
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return index
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return index
+	}
+
+	for _, n := range doc.Content {
+		indexYamlNode(n, "", index)
+	}
+	return index
+}
+
+func indexYamlNode(n *yaml.Node, path string, index map[string]yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+
+			childPath := path
+			// Nested fields are addressed by their names only
+			collapsed := path != "" && (key.Value == "properties" || key.Value == "item_type" || key.Value == "value_type")
+			if !collapsed {
+				childPath = joinYamlPath(path, key.Value)
+				if _, ok := index[childPath]; !ok {
+					index[childPath] = *key
+				}
+			}
+			indexYamlNode(value, childPath, index)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			segment := strconv.Itoa(i)
+			if name := yamlMappingValue(item, "name"); name != "" {
+				segment = name
+			}
+			childPath := joinYamlPath(path, segment)
+			if _, ok := index[childPath]; !ok {
+				index[childPath] = *item
+			}
+			indexYamlNode(item, childPath, index)
+		}
+	}
+}
+
+func yamlMappingValue(n *yaml.Node, key string) string {
+	if n.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func joinYamlPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	if segment == "" {
+		return path
+	}
+	return fmt.Sprintf("%s.%s", path, segment)
+}
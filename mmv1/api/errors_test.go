@@ -0,0 +1,170 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidationErrorsResolvePositions(t *testing.T) {
+	t.Parallel()
+
+	yamlPath := filepath.Join(t.TempDir(), "Resource.yaml")
+	content := `name: 'Resource'
+create_verb: 'GET'
+properties:
+  - name: 'networkConfig'
+    type: NestedObject
+    properties:
+      - name: 'subnet'
+        type: String
+  - name: 'rules'
+    type: Array
+    item_type:
+      type: NestedObject
+      properties:
+        - name: 'action'
+          type: String
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		description string
+		path        string
+		line        int
+		column      int
+	}{
+		{
+			description: "top-level field",
+			path:        "create_verb",
+			line:        2,
+			column:      1,
+		},
+		{
+			description: "nested object property",
+			path:        "properties.networkConfig.subnet",
+			line:        7,
+			column:      9,
+		},
+		{
+			description: "array item type property",
+			path:        "properties.rules.action",
+			line:        14,
+			column:      11,
+		},
+		{
+			description: "unknown field falls back to its parent",
+			path:        "properties.networkConfig.missing",
+			line:        4,
+			column:      5,
+		},
+		{
+			description: "unknown top-level field has no position",
+			path:        "description",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			errs := ValidationErrors{{File: yamlPath, Path: tc.path, Message: "test"}}
+			errs.ResolvePositions()
+
+			if got, want := errs[0].Line, tc.line; got != want {
+				t.Errorf("expected line %d to be %d", got, want)
+			}
+			if got, want := errs[0].Column, tc.column; got != want {
+				t.Errorf("expected column %d to be %d", got, want)
+			}
+		})
+	}
+}
+
+func TestTypeYamlPath(t *testing.T) {
+	t.Parallel()
+
+	subnet := &Type{Name: "subnet", Type: "String"}
+	networkConfig := &Type{Name: "networkConfig", Type: "NestedObject", Properties: []*Type{subnet}}
+	action := &Type{Name: "action", Type: "String"}
+	rules := &Type{Name: "rules", Type: "Array", ItemType: &Type{Type: "NestedObject", Properties: []*Type{action}}}
+	zone := &Type{Name: "zone", Type: "String"}
+	r := &Resource{
+		Name:       "Resource",
+		Properties: []*Type{networkConfig, rules},
+		Parameters: []*Type{zone},
+		ProductMetadata: &Product{
+			Name: "Product",
+		},
+	}
+	r.SetDefault(r.ProductMetadata)
+
+	cases := []struct {
+		description string
+		obj         *Type
+		expected    string
+	}{
+		{
+			description: "top-level property",
+			obj:         networkConfig,
+			expected:    "properties.networkConfig",
+		},
+		{
+			description: "parameter",
+			obj:         zone,
+			expected:    "parameters.zone",
+		},
+		{
+			description: "nested object property",
+			obj:         subnet,
+			expected:    "properties.networkConfig.subnet",
+		},
+		{
+			description: "array item type property",
+			obj:         action,
+			expected:    "properties.rules.action",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.obj.YamlPath(), tc.expected; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompileReturnsErrors(t *testing.T) {
+	t.Parallel()
+
+	yamlPath := filepath.Join(t.TempDir(), "Resource.yaml")
+	content := "name: 'Resource'\nunknown_field: true\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := Compile(yamlPath, &Resource{}, "")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if got, want := errs[0].File, yamlPath; got != want {
+		t.Errorf("expected file %q to be %q", got, want)
+	}
+	if got, want := errs[0].Line, 2; got != want {
+		t.Errorf("expected line %d to be %d", got, want)
+	}
+
+	errs = Compile(filepath.Join(t.TempDir(), "Missing.yaml"), &Resource{}, "")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for a missing file, got %d: %v", len(errs), errs)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// A check of a resource definition against the API Improvement Proposals
+// (https://google.aip.dev) that most GCP resource APIs follow. Unlike
+// validation errors, findings are conventions the YAML likely contradicts,
+// and can be suppressed with `lint_suppressions` where the API deviates from
+// them.
+type lintRule struct {
+	// Identifies the rule in findings and in `lint_suppressions`
+	id string
+
+	// The AIP describing the convention
+	aip int
+
+	check func(r *Resource) ValidationErrors
+}
+
+var lintRules = []lintRule{
+	{id: "aip-134-update-verb", aip: 134, check: lintUpdateVerb},
+	{id: "aip-134-update-mask", aip: 134, check: lintUpdateMask},
+	{id: "aip-151-async", aip: 151, check: lintAsync},
+	{id: "aip-122-id-format", aip: 122, check: lintIdFormat},
+	{id: "aip-122-immutable-name", aip: 122, check: lintImmutableName},
+}
+
+// Returns the IDs of all lint rules, in the order they are run.
+func LintRuleIds() []string {
+	var ids []string
+	for _, rule := range lintRules {
+		ids = append(ids, rule.id)
+	}
+	return ids
+}
+
+// Runs the lint rules over every resource of the product that isn't
+// excluded.
+func (p Product) Lint() ValidationErrors {
+	var findings ValidationErrors
+	for _, r := range p.Objects {
+		if r.IsExcluded() {
+			continue
+		}
+		findings.Append(r.Lint())
+	}
+	return findings
+}
+
+// Runs the lint rules over the resource, except the ones listed in its
+// `lint_suppressions`. Findings are located in the resource's SourceYamlFile
+// and carry the ID of their rule.
+func (r *Resource) Lint() ValidationErrors {
+	var findings ValidationErrors
+
+	ids := LintRuleIds()
+	for i, id := range r.LintSuppressions {
+		if !slices.Contains(ids, id) {
+			findings = append(findings, &ValidationError{
+				Path:    fmt.Sprintf("lint_suppressions.%d", i),
+				Message: fmt.Sprintf("Unknown lint rule %q, should be one of %#v", id, ids),
+			})
+		}
+	}
+
+	for _, rule := range lintRules {
+		if slices.Contains(r.LintSuppressions, rule.id) {
+			continue
+		}
+		for _, finding := range rule.check(r) {
+			finding.Rule = rule.id
+			finding.Message = fmt.Sprintf("%s (https://google.aip.dev/%d)", finding.Message, rule.aip)
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings.InFile(r.SourceYamlFile)
+}
+
+var createIdParamRegexp = regexp.MustCompile(`[?&]\w+Id=\{\{`)
+
+// Returns true if the resource is created with a client-assigned ID in the
+// query string of its create URL, as described by AIP-133. The lint rules
+// only apply to these resources, as older APIs predate the AIPs.
+func (r Resource) isResourceOriented() bool {
+	return createIdParamRegexp.MatchString(r.CreateUrl)
+}
+
+// Returns true if some fields of the resource are updated by the standard
+// update method, rather than being immutable or using their own update_url.
+func (r Resource) hasStandardUpdate() bool {
+	if !r.Updatable() {
+		return false
+	}
+	for _, p := range r.AllUserProperties() {
+		if !p.Output && !p.UrlParamOnly && !p.IsForceNew() && p.UpdateUrl == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// AIP-134: resources are updated with PATCH and a field mask. PUT is the
+// default update_verb, so it's easy to leave it by mistake.
+func lintUpdateVerb(r *Resource) ValidationErrors {
+	var findings ValidationErrors
+	if r.isResourceOriented() && r.hasStandardUpdate() && r.UpdateVerb == "PUT" {
+		findings.Add("update_verb", "Resource-oriented APIs update resources with PATCH and an update mask, set `update_verb: 'PATCH'` and `update_mask: true`")
+	}
+	return findings
+}
+
+// AIP-134: a PATCH without a field mask updates every field, clearing the
+// fields that aren't sent.
+func lintUpdateMask(r *Resource) ValidationErrors {
+	var findings ValidationErrors
+	if !r.isResourceOriented() || !r.hasStandardUpdate() || r.UpdateVerb != "PATCH" || r.UpdateMask {
+		return findings
+	}
+	// The mask may be built by custom code, or set in the update URL
+	if r.CustomCode.CustomUpdate != "" || r.CustomCode.PreUpdate != "" || r.CustomCode.UpdateEncoder != "" || strings.Contains(r.UpdateUrl, "updateMask") {
+		return findings
+	}
+	findings.Add("update_mask", "PATCH requests should send the fields to update in an update mask, set `update_mask: true`")
+	return findings
+}
+
+// AIP-151: the standard methods of an API either all return long-running
+// operations or none do, so a resource without `async` in a product where
+// other resources poll operations likely misses it.
+func lintAsync(r *Resource) ValidationErrors {
+	var findings ValidationErrors
+	if !r.isResourceOriented() || r.GetAsync() != nil || r.ProductMetadata == nil {
+		return findings
+	}
+	for _, other := range r.ProductMetadata.Objects {
+		if other == r || !other.isResourceOriented() {
+			continue
+		}
+		if async := other.GetAsync(); async != nil && async.IsA("OpAsync") {
+			findings.Add("", "Other resources of %s return long-running operations, add an `async` block if the methods of %s do too", r.ProductMetadata.Name, r.Name)
+			break
+		}
+	}
+	return findings
+}
+
+// AIP-122: the resource name identifies a resource, so the Terraform ID
+// should follow the self link rather than a different format.
+func lintIdFormat(r *Resource) ValidationErrors {
+	var findings ValidationErrors
+	if !r.isResourceOriented() || r.IdFormat == "" || r.SelfLink == "" || !strings.Contains(r.SelfLink, "/") {
+		return findings
+	}
+	if normalizeUrlFormat(r.IdFormat) != normalizeUrlFormat(r.SelfLink) {
+		findings.Add("id_format", "`id_format` %q doesn't match the resource name in `self_link` %q", r.IdFormat, r.SelfLink)
+	}
+	return findings
+}
+
+// Drops the parts of a URL format that don't change the resource name it
+// refers to: the `%` of unescaped placeholders and query parameters.
+func normalizeUrlFormat(format string) string {
+	format, _, _ = strings.Cut(format, "?")
+	return strings.ReplaceAll(format, "{{%", "{{")
+}
+
+// AIP-122: resource names can't change, so a user-set `name` must be
+// immutable.
+func lintImmutableName(r *Resource) ValidationErrors {
+	var findings ValidationErrors
+	if !r.isResourceOriented() {
+		return findings
+	}
+	for _, p := range append(slices.Clone(r.Parameters), r.Properties...) {
+		if p.Name != "name" || p.Output || p.IsForceNew() {
+			continue
+		}
+		key := "properties"
+		if slices.Contains(r.Parameters, p) {
+			key = "parameters"
+		}
+		findings.Add(fmt.Sprintf("%s.name", key), "The `name` of a resource can't be updated, mark it `immutable: true`")
+	}
+	return findings
+}
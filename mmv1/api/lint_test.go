@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestResourceLint(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		update      func(r *Resource)
+		siblingOp   bool
+		expected    []string
+	}{
+		{
+			description: "follows the AIPs",
+			update:      func(r *Resource) {},
+			siblingOp:   true,
+		},
+		{
+			description: "default update verb",
+			update:      func(r *Resource) { r.UpdateVerb = "" },
+			expected:    []string{"update_verb [aip-134-update-verb]"},
+		},
+		{
+			description: "immutable resource with the default update verb",
+			update: func(r *Resource) {
+				r.UpdateVerb = ""
+				r.Immutable = true
+			},
+		},
+		{
+			description: "patch without an update mask",
+			update:      func(r *Resource) { r.UpdateMask = false },
+			expected:    []string{"update_mask [aip-134-update-mask]"},
+		},
+		{
+			description: "update mask built by custom code",
+			update: func(r *Resource) {
+				r.UpdateMask = false
+				r.CustomCode.PreUpdate = "templates/terraform/pre_update/widget.go.tmpl"
+			},
+		},
+		{
+			description: "no async where other resources use operations",
+			update:      func(r *Resource) { r.Async = nil },
+			siblingOp:   true,
+			expected:    []string{" [aip-151-async]"},
+		},
+		{
+			description: "no async in a product without operations",
+			update:      func(r *Resource) { r.Async = nil },
+		},
+		{
+			description: "id format different from the self link",
+			update:      func(r *Resource) { r.IdFormat = "{{name}}" },
+			expected:    []string{"id_format [aip-122-id-format]"},
+		},
+		{
+			description: "id format with an unescaped name",
+			update:      func(r *Resource) { r.IdFormat = "projects/{{project}}/widgets/{{%name}}" },
+		},
+		{
+			description: "mutable name",
+			update:      func(r *Resource) { r.Parameters[0].Immutable = false },
+			expected:    []string{"parameters.name [aip-122-immutable-name]"},
+		},
+		{
+			description: "API that predates the AIPs",
+			update: func(r *Resource) {
+				r.CreateUrl = "projects/{{project}}/widgets"
+				r.UpdateVerb = ""
+				r.Parameters[0].Immutable = false
+			},
+		},
+		{
+			description: "suppressed rule",
+			update: func(r *Resource) {
+				r.UpdateVerb = ""
+				r.LintSuppressions = []string{"aip-134-update-verb"}
+			},
+		},
+		{
+			description: "unknown suppressed rule",
+			update:      func(r *Resource) { r.LintSuppressions = []string{"aip-0-unknown"} },
+			expected:    []string{"lint_suppressions.0 []"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := &Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				BaseUrl:     "projects/{{project}}/widgets",
+				SelfLink:    "projects/{{project}}/widgets/{{name}}",
+				CreateUrl:   "projects/{{project}}/widgets?widgetId={{name}}",
+				UpdateVerb:  "PATCH",
+				UpdateMask:  true,
+				Async:       &Async{Type: "OpAsync", Actions: []string{"create", "delete", "update"}},
+				Parameters: []*Type{
+					{Name: "name", Type: "String", UrlParamOnly: true, Required: true, Immutable: true},
+				},
+				Properties: []*Type{
+					{Name: "displayName", Type: "String"},
+				},
+			}
+			sibling := &Resource{
+				Name:       "Gadget",
+				BaseUrl:    "projects/{{project}}/gadgets",
+				CreateUrl:  "projects/{{project}}/gadgets?gadgetId={{name}}",
+				Properties: []*Type{{Name: "name", Type: "String", Output: true}},
+			}
+			if tc.siblingOp {
+				sibling.Async = &Async{Type: "OpAsync", Actions: []string{"create"}}
+			}
+			tc.update(r)
+
+			p := &Product{Name: "Example", Objects: []*Resource{r, sibling}}
+			r.SetDefault(p)
+			sibling.SetDefault(p)
+
+			var findings []string
+			for _, f := range r.Lint() {
+				findings = append(findings, fmt.Sprintf("%s [%s]", f.Path, f.Rule))
+			}
+			if got, want := findings, tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected findings %v to be %v", got, want)
+			}
+		})
+	}
+}
@@ -73,8 +73,20 @@ type Product struct {
 
 	ClientName string `yaml:"client_name,omitempty"`
 
+	// Values shared by every resource of the product, such as timeouts,
+	// error_retry_predicates, mutex, import_format or sweeper prefixes. They are
+	// merged into each resource before its defaults are set, and values set in
+	// the resource YAML take precedence.
+	ResourceDefaults *Resource `yaml:"resource_defaults,omitempty"`
+
 	// The compiler to generate the downstream files, for example "terraformgoogleconversion-codegen".
 	Compiler string `yaml:"-"`
+
+	// The YAML files the product was read from, including overrides.
+	YamlFiles []string `yaml:"-"`
+
+	// The directory of the overrides the product was read with, if any.
+	OverrideDirectory string `yaml:"-"`
 }
 
 func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
@@ -91,15 +103,16 @@ func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+func (p *Product) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		errs.Add("name", "Missing `name` for product")
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			errs.Add("name", "product name `%s` must start with a capital letter.", p.Name)
 		}
 		if i == 0 {
 			break
@@ -107,20 +120,130 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		errs.Add("scopes", "Missing `scopes` for product %s", p.Name)
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		errs.Add("versions", "Missing `versions` for product %s", p.Name)
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		errs.AppendErrors(joinYamlPath("versions", v.Name), v.Validate(p.Name))
 	}
 
 	if p.Async != nil {
-		p.Async.Validate()
+		errs.Append(p.Async.Validate().WithPathPrefix("async"))
+	}
+
+	errs.Append(p.validateResourceDefaults())
+
+	return errs
+}
+
+// Fields of resource_defaults that only make sense for a single resource.
+var resourceOnlyFields = []string{"name", "base_url", "self_link", "id_format", "properties", "parameters", "virtual_fields", "examples"}
+
+func (p *Product) validateResourceDefaults() ValidationErrors {
+	var errs ValidationErrors
+	if p.ResourceDefaults == nil {
+		return errs
+	}
+
+	d := reflect.ValueOf(*p.ResourceDefaults)
+	for i := 0; i < d.NumField(); i++ {
+		field := d.Type().Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		if slices.Contains(resourceOnlyFields, key) && !d.Field(i).IsZero() {
+			errs.Add(joinYamlPath("resource_defaults", key), "`%s` can't be set in `resource_defaults`, as it's specific to each resource", key)
+		}
+	}
+	return errs
+}
+
+// Merges the product's resource_defaults into r. Values set in r take
+// precedence, and nested blocks such as timeouts and sweeper are merged field
+// by field. As with overrides, a value can't be reset to its zero value, so a
+// resource can't set a boolean that is true in the defaults back to false.
+func (p *Product) ApplyResourceDefaults(r *Resource) {
+	if p.ResourceDefaults == nil {
+		return
+	}
+
+	// Resources get their own copy of the defaults, as setting their defaults
+	// modifies nested values such as timeouts.
+	defaults := deepCopy(reflect.ValueOf(p.ResourceDefaults))
+	mergeDefaults(defaults, reflect.ValueOf(r))
+	*r = *defaults.Interface().(*Resource)
+}
+
+// Returns a copy of v that shares no pointers, slices or maps with it.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// Merges otherObj into self like Merge, but merges the fields of nested
+// structs set in both instead of replacing them.
+func mergeDefaults(self, otherObj reflect.Value) {
+	selfObj := reflect.Indirect(self)
+	otherObj = reflect.Indirect(otherObj)
+	for i := 0; i < selfObj.NumField(); i++ {
+		if !selfObj.Type().Field(i).IsExported() {
+			continue
+		}
+
+		selfField, otherField := selfObj.Field(i), otherObj.Field(i)
+		switch {
+		case selfField.Kind() == reflect.Struct:
+		case selfField.Kind() == reflect.Ptr && selfField.Type().Elem().Kind() == reflect.Struct:
+			if selfField.IsNil() || otherField.IsNil() {
+				continue
+			}
+		default:
+			continue
+		}
+		mergeDefaults(selfField, otherField)
+		otherField.Set(selfField)
 	}
+	Merge(selfObj, otherObj)
 }
 
 // ====================
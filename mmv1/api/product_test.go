@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestProductLowestVersion(t *testing.T) {
@@ -238,3 +239,98 @@ func TestProductServiceVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestProductApplyResourceDefaults(t *testing.T) {
+	t.Parallel()
+
+	defaults := &Resource{
+		Mutex:                "projects/{{project}}/widgets",
+		ImportFormat:         []string{"projects/{{project}}/widgets/{{name}}"},
+		Timeouts:             &Timeouts{InsertMinutes: 30, UpdateMinutes: 30},
+		ErrorRetryPredicates: []string{"transport_tpg.IsWidgetBusyError"},
+		Sweeper:              resource.Sweeper{Prefixes: []string{"tf-test-"}},
+	}
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    Resource
+	}{
+		{
+			description: "resource without values",
+			obj:         Resource{Name: "Widget"},
+			expected: Resource{
+				Name:                 "Widget",
+				Mutex:                "projects/{{project}}/widgets",
+				ImportFormat:         []string{"projects/{{project}}/widgets/{{name}}"},
+				Timeouts:             &Timeouts{InsertMinutes: 30, UpdateMinutes: 30},
+				ErrorRetryPredicates: []string{"transport_tpg.IsWidgetBusyError"},
+				Sweeper:              resource.Sweeper{Prefixes: []string{"tf-test-"}},
+			},
+		},
+		{
+			description: "resource overrides",
+			obj: Resource{
+				Name:                    "Widget",
+				Mutex:                   "widgets/{{name}}",
+				ImportFormat:            []string{"widgets/{{name}}"},
+				Timeouts:                &Timeouts{UpdateMinutes: 10, DeleteMinutes: 10},
+				Sweeper:                 resource.Sweeper{Dependencies: []string{"google_example_gadget"}},
+				ExcludeAttributionLabel: true,
+			},
+			expected: Resource{
+				Name:                    "Widget",
+				Mutex:                   "widgets/{{name}}",
+				ImportFormat:            []string{"widgets/{{name}}"},
+				Timeouts:                &Timeouts{InsertMinutes: 30, UpdateMinutes: 10, DeleteMinutes: 10},
+				ErrorRetryPredicates:    []string{"transport_tpg.IsWidgetBusyError"},
+				Sweeper:                 resource.Sweeper{Prefixes: []string{"tf-test-"}, Dependencies: []string{"google_example_gadget"}},
+				ExcludeAttributionLabel: true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Product{Name: "Example", ResourceDefaults: defaults}
+			p.ApplyResourceDefaults(&tc.obj)
+			if got, want := tc.obj, tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %+v to be %+v", got, want)
+			}
+		})
+	}
+
+	// Resources don't share the nested values of the defaults
+	p := &Product{Name: "Example", ResourceDefaults: defaults}
+	r := &Resource{Name: "Widget"}
+	p.ApplyResourceDefaults(r)
+	r.Timeouts.DeleteMinutes = 20
+	if defaults.Timeouts.DeleteMinutes != 0 {
+		t.Errorf("expected the defaults not to be modified by resources")
+	}
+}
+
+func TestProductValidateResourceDefaults(t *testing.T) {
+	t.Parallel()
+
+	p := Product{
+		ResourceDefaults: &Resource{
+			Name:     "Widget",
+			Mutex:    "widgets",
+			BaseUrl:  "widgets",
+			Examples: []resource.Examples{{Name: "widget_basic"}},
+		},
+	}
+
+	var paths []string
+	for _, err := range p.validateResourceDefaults() {
+		paths = append(paths, err.Path)
+	}
+	if got, want := paths, []string{"resource_defaults.name", "resource_defaults.base_url", "resource_defaults.examples"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected errors at %v to be at %v", got, want)
+	}
+}
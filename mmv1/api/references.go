@@ -0,0 +1,230 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Placeholders that are filled in from provider-level configuration rather
+// than from a field of the resource.
+var providerPlaceholders = []string{"project", "region", "zone", "universe_domain"}
+
+var placeholderRegexp = regexp.MustCompile(`{{%?([[:word:]]+)}}`)
+
+// Checks that the names a resource uses to refer to its own fields and to
+// template files actually exist. These checks are more expensive than the
+// ones in Validate and are run by `--validate-only`.
+//
+// This covers:
+// * placeholders in `id_format`, `import_format` and `self_link`
+// * `update_mask_fields`
+// * `conflicts`, `exactly_one_of`, `at_least_one_of` and `required_with`
+// * `custom_code`, `custom_expand` and `custom_flatten` template paths
+//
+// Resources that aren't generated, such as the ones only defining IAM
+// resources, aren't checked.
+func (r *Resource) ValidateReferences() ValidationErrors {
+	var errs ValidationErrors
+
+	if r.IsExcluded() {
+		return errs
+	}
+
+	// An id_format defaulted by SetDefault is only checked through the
+	// self_link it was copied from, as the base_url/{{name}} default is
+	// conventional for resources without a name
+	if r.IdFormat != r.SelfLinkUri() {
+		errs.Append(r.validatePlaceholders("id_format", r.IdFormat))
+	}
+	errs.Append(r.validatePlaceholders("self_link", r.SelfLink))
+	// Custom import code parses the import id itself
+	if r.CustomCode.CustomImport == "" {
+		for i, f := range r.ImportFormat {
+			errs.Append(r.validatePlaceholders(fmt.Sprintf("import_format.%d", i), f))
+		}
+	}
+
+	errs.Append(r.validateCustomCodePaths())
+
+	for _, p := range r.AllNestedProperties(google.Concat(r.AllUserProperties(), r.UserVirtualFields())) {
+		errs.Append(r.validatePropertyReferences(p))
+	}
+
+	return errs.InFile(r.SourceYamlFile)
+}
+
+func (r *Resource) validatePlaceholders(path, format string) ValidationErrors {
+	var errs ValidationErrors
+	if format == "" {
+		return errs
+	}
+
+	fields := r.placeholderFields()
+	for _, m := range placeholderRegexp.FindAllStringSubmatch(format, -1) {
+		if !slices.Contains(fields, m[1]) {
+			errs.Add(path, "Placeholder `%s` in `%s` does not match any property or parameter of resource %s", m[0], format, r.Name)
+		}
+	}
+	return errs
+}
+
+// Returns the names usable as placeholders in the resource's URLs and ids.
+func (r *Resource) placeholderFields() []string {
+	fields := slices.Clone(providerPlaceholders)
+	for _, p := range google.Concat(r.RootProperties(), r.UserVirtualFields()) {
+		fields = append(fields, google.Underscore(p.Name))
+	}
+	return fields
+}
+
+func (r *Resource) validatePropertyReferences(p *Type) ValidationErrors {
+	var errs ValidationErrors
+	path := p.YamlPath()
+
+	for _, mask := range p.UpdateMaskFields {
+		if !r.hasUpdateMaskField(p, mask) {
+			errs.Add(joinYamlPath(path, "update_mask_fields"), "`update_mask_fields` entry `%s` on property %s does not match any field of resource %s", mask, p.Name, r.Name)
+		}
+	}
+
+	relations := []struct {
+		key   string
+		paths []string
+	}{
+		{"conflicts", p.Conflicts},
+		{"exactly_one_of", p.ExactlyOneOf},
+		{"at_least_one_of", p.AtLeastOneOf},
+		{"required_with", p.RequiredWith},
+	}
+	for _, rel := range relations {
+		// Schema paths can't address fields within lists or maps, so these
+		// entries are only documentation
+		if hasCollectionAncestor(p) {
+			break
+		}
+		for _, fieldPath := range rel.paths {
+			// Entries the generator can't resolve are silently dropped from the schema
+			if p.GetPropertySchemaPath(fieldPath) == "" {
+				errs.Add(joinYamlPath(path, rel.key), "`%s` entry `%s` on property %s does not match any field of resource %s", rel.key, fieldPath, p.Name, r.Name)
+			}
+		}
+	}
+
+	templates := []struct {
+		key  string
+		path string
+	}{
+		{"custom_expand", p.CustomExpand},
+		{"custom_flatten", p.CustomFlatten},
+	}
+	for _, tmpl := range templates {
+		if tmpl.path != "" && !templateExists(tmpl.path) {
+			errs.Add(joinYamlPath(path, tmpl.key), "Template `%s` does not exist", tmpl.path)
+		}
+	}
+
+	return errs
+}
+
+func hasCollectionAncestor(p *Type) bool {
+	for parent := p.ParentMetadata; parent != nil; parent = parent.ParentMetadata {
+		if parent.IsA("Array") || parent.IsA("Map") {
+			return true
+		}
+	}
+	return false
+}
+
+// Update mask entries are API field paths such as `networkConfig.subnet`,
+// where `*` matches any field. They are relative to the resource, or to any of the property's ancestors
+// for properties updated through a dedicated call on a nested object.
+func (r *Resource) hasUpdateMaskField(p *Type, mask string) bool {
+	// Excluded properties count, as they may be sent by encoders without
+	// being part of the schema
+	roots := [][]*Type{google.Concat(r.Properties, r.Parameters)}
+	for parent := p.ParentMetadata; parent != nil; parent = parent.ParentMetadata {
+		roots = append(roots, parent.NestedProperties())
+	}
+
+	segments := strings.Split(mask, ".")
+	for _, props := range roots {
+		if hasApiFieldPath(props, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasApiFieldPath(props []*Type, segments []string) bool {
+	if len(segments) == 0 {
+		return true
+	}
+
+	for _, p := range props {
+		if p.FlattenObject && hasApiFieldPath(p.NestedProperties(), segments) {
+			return true
+		}
+		if segments[0] != "*" && !matchesFieldName(p, segments[0]) {
+			continue
+		}
+		nested := p.NestedProperties()
+		// Paths into maps or untyped objects can't be checked any further
+		if len(nested) == 0 {
+			return true
+		}
+		if hasApiFieldPath(nested, segments[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFieldName(p *Type, name string) bool {
+	return p.ApiName == name || p.Name == name ||
+		google.Underscore(p.ApiName) == name || google.Underscore(p.Name) == name
+}
+
+func (r *Resource) validateCustomCodePaths() ValidationErrors {
+	var errs ValidationErrors
+
+	v := reflect.ValueOf(r.CustomCode)
+	for i := 0; i < v.NumField(); i++ {
+		templatePath := v.Field(i).String()
+		if templatePath == "" || templateExists(templatePath) {
+			continue
+		}
+
+		key := google.Underscore(v.Type().Field(i).Name)
+		if tag, ok := v.Type().Field(i).Tag.Lookup("yaml"); ok {
+			key = strings.Split(tag, ",")[0]
+		}
+		errs.Add(joinYamlPath("custom_code", key), "Template `%s` does not exist", templatePath)
+	}
+
+	return errs
+}
+
+func templateExists(templatePath string) bool {
+	_, err := os.Stat(templatePath)
+	return !errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestResourceValidateReferences(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "known placeholders",
+			obj: Resource{
+				Name:     "Resource",
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/resources",
+				IdFormat: "projects/{{project}}/locations/{{location}}/resources/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "unknown placeholder",
+			obj: Resource{
+				Name:     "Resource",
+				BaseUrl:  "projects/{{project}}/resources",
+				IdFormat: "projects/{{project}}/resources/{{resource_id}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			expected: []string{"id_format"},
+		},
+		{
+			description: "id format defaulted from the base url",
+			obj: Resource{
+				Name:    "Resource",
+				BaseUrl: "projects/{{project}}/resourceConfig",
+				Properties: []*Type{
+					{Name: "displayName", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "excluded resource",
+			obj: Resource{
+				Name:            "Resource",
+				BaseUrl:         "projects/{{project}}/resources",
+				IdFormat:        "{{resource_id}}",
+				ExcludeResource: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+		},
+		{
+			description: "update mask fields",
+			obj: Resource{
+				Name:    "Resource",
+				BaseUrl: "projects/{{project}}/resources/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{
+						Name:             "networkConfig",
+						Type:             "NestedObject",
+						UpdateMaskFields: []string{"networkConfig.subnet", "networkConfig.missing"},
+						Properties: []*Type{
+							{Name: "subnet", Type: "String"},
+						},
+					},
+					{
+						Name:             "rotationPeriod",
+						Type:             "String",
+						UpdateMaskFields: []string{"rotationPeriod", "nextRotationTime"},
+					},
+					{Name: "nextRotationTime", Type: "String", Exclude: true},
+					{
+						Name:             "rules",
+						Type:             "Array",
+						UpdateMaskFields: []string{"rules.*.action"},
+						ItemType: &Type{
+							Type: "NestedObject",
+							Properties: []*Type{
+								{Name: "action", Type: "String"},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"properties.networkConfig.update_mask_fields"},
+		},
+		{
+			description: "field relations",
+			obj: Resource{
+				Name:    "Resource",
+				BaseUrl: "projects/{{project}}/resources/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{Name: "foo", Type: "String", ExactlyOneOf: []string{"foo", "bar"}},
+					{Name: "bar", Type: "String", Conflicts: []string{"baz"}},
+					{
+						Name: "rules",
+						Type: "Array",
+						ItemType: &Type{
+							Type: "NestedObject",
+							Properties: []*Type{
+								{Name: "action", Type: "String", Conflicts: []string{"rules.0.missing"}},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"properties.bar.conflicts"},
+		},
+		{
+			description: "field relations relative to ancestors",
+			obj: Resource{
+				Name:    "Resource",
+				BaseUrl: "projects/{{project}}/resources/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+					{
+						Name: "config",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{
+								Name:         "source",
+								Type:         "NestedObject",
+								AtLeastOneOf: []string{"repo", "bucket"},
+								Properties: []*Type{
+									{Name: "repo", Type: "String", ExactlyOneOf: []string{"repo", "bucketName"}},
+									{Name: "bucketName", Type: "String", Conflicts: []string{"source.0.repo", "missing"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"properties.config.source.at_least_one_of", "properties.config.source.at_least_one_of", "properties.config.source.bucketName.conflicts"},
+		},
+		{
+			description: "missing templates",
+			obj: Resource{
+				Name:    "Resource",
+				BaseUrl: "projects/{{project}}/resources/{{name}}",
+				CustomCode: resource.CustomCode{
+					Constants: "templates/terraform/constants/does_not_exist.go.tmpl",
+				},
+				Properties: []*Type{
+					{Name: "name", Type: "String", CustomFlatten: "templates/terraform/custom_flatten/does_not_exist.go.tmpl"},
+				},
+			},
+			expected: []string{"custom_code.constants", "properties.name.custom_flatten"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := tc.obj
+			r.ProductMetadata = &Product{Name: "Product"}
+			r.SetDefault(r.ProductMetadata)
+
+			errs := r.ValidateReferences()
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			if len(paths) != len(tc.expected) {
+				t.Fatalf("expected errors at %v, got %v", tc.expected, errs)
+			}
+			for i := range paths {
+				if paths[i] != tc.expected[i] {
+					t.Errorf("expected error path %q to be %q", paths[i], tc.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -118,6 +118,10 @@ type Resource struct {
 	// [Optional] The HTTP verb used during delete. Defaults to DELETE.
 	DeleteVerb string `yaml:"delete_verb,omitempty"`
 
+	// [Optional] Groups of top-level fields updated through calls of their
+	// own, in order, after the main update. See CustomUpdateGroup.
+	UpdateGroups []*CustomUpdateGroup `yaml:"update_groups,omitempty"`
+
 	// [Optional] Additional Query Parameters to append to GET. Defaults to ""
 	ReadQueryParams string `yaml:"read_query_params,omitempty"`
 
@@ -159,6 +163,40 @@ type Resource struct {
 	// TODO rewrite: rename?
 	ExcludeResource bool `yaml:"exclude_resource,omitempty"`
 
+	// ====================
+	// Data Source Configuration
+	// ====================
+	//
+	// [Optional] (Api::Resource::Datasource) Configuration of a data source
+	// generated from the resource, which reads a single instance of it.
+	Datasource *resource.Datasource `yaml:"datasource,omitempty"`
+
+	// [Optional] (Api::Resource::ListDatasource) Configuration of a data
+	// source generated from the resource, which lists all instances of it
+	// under a parent.
+	ListDatasource *resource.ListDatasource `yaml:"list_datasource,omitempty"`
+
+	// [Optional] (Api::Resource::ListResource) Configuration of a Terraform
+	// list resource generated for the resource, used to discover existing
+	// instances of it for import.
+	ListResource *resource.ListResource `yaml:"list_resource,omitempty"`
+
+	// [Optional] (Api::Resource::Ephemeral) Configuration of a Terraform
+	// ephemeral resource generated for the resource, which returns values
+	// that shouldn't be stored in state.
+	Ephemeral *resource.Ephemeral `yaml:"ephemeral,omitempty"`
+
+	// [Optional] (Api::Action) Custom methods of the resource, such as
+	// `:restart`, generated as Terraform actions.
+	Actions []*Action `yaml:"actions,omitempty"`
+
+	// [Optional] If true, the resource is implemented with the plugin
+	// framework instead of the SDK. Its schema, plan modifiers and validators
+	// are generated for the framework, while its CRUD functions and their
+	// expanders and flatteners are shared with the SDK implementation, so
+	// existing state keeps working.
+	Framework bool `yaml:"framework,omitempty"`
+
 	// [Optional] GCP kind, e.g. `compute//disk`
 	Kind string `yaml:"kind,omitempty"`
 
@@ -263,6 +301,12 @@ type Resource struct {
 
 	StateUpgraders bool `yaml:"state_upgraders,omitempty"`
 
+	// The snapshots of the resource's schema recorded for earlier major
+	// versions of the provider, sorted by major version. Unless
+	// state_upgraders is set, the state upgraders of the resource are
+	// generated from them when its schema_version is increased.
+	SchemaSnapshots []*resource.SchemaSnapshot `yaml:"-"`
+
 	// Do not apply the default attribution label
 	ExcludeAttributionLabel bool `yaml:"exclude_attribution_label,omitempty"`
 
@@ -334,6 +378,15 @@ type Resource struct {
 	// in API payloads are better handled with custom expand/encoder logic.
 	VirtualFields []*Type `yaml:"virtual_fields,omitempty"`
 
+	// Generates a guard that prevents Terraform from deleting the resource
+	// while `deletion_protection` is enabled, along with the virtual field
+	// itself unless the guard is backed by an API field.
+	DeletionProtection *resource.DeletionProtection `yaml:"deletion_protection,omitempty"`
+
+	// The IDs of the lint rules that don't apply to this resource, as its API
+	// deviates from the AIP they check. See the --lint flag and api/lint.go.
+	LintSuppressions []string `yaml:"lint_suppressions,omitempty"`
+
 	Parameters []*Type
 
 	Properties []*Type
@@ -362,6 +415,10 @@ type Resource struct {
 
 	ImportPath     string `yaml:"-"`
 	SourceYamlFile string `yaml:"-"`
+
+	// The YAML files the resource was read from, including overrides and
+	// schema snapshots.
+	YamlFiles []string `yaml:"-"`
 }
 
 func (r *Resource) UnmarshalYAML(unmarshal func(any) error) error {
@@ -400,6 +457,7 @@ func (r *Resource) SetDefault(product *Product) {
 		r.IdFormat = r.SelfLinkUri()
 	}
 
+	r.addDeletionProtectionField()
 	if len(r.VirtualFields) > 0 {
 		for _, f := range r.VirtualFields {
 			f.ClientSide = true
@@ -407,6 +465,7 @@ func (r *Resource) SetDefault(product *Product) {
 	}
 
 	r.ProductMetadata = product
+	r.setUpdateGroupDefaults()
 	for _, property := range r.AllProperties() {
 		property.SetDefault(r)
 	}
@@ -419,77 +478,119 @@ func (r *Resource) SetDefault(product *Product) {
 	if r.Timeouts == nil {
 		r.Timeouts = NewTimeouts()
 	}
-
+	for _, a := range r.Actions {
+		a.SetDefault(r)
+	}
+	r.setDeletionProtectionDefaults()
 }
 
-func (r *Resource) Validate() {
+// Validates the resource and all of its fields. Every problem found is
+// returned, located in the resource's SourceYamlFile.
+func (r *Resource) Validate() ValidationErrors {
+	var errs ValidationErrors
+
 	if r.Name == "" {
-		log.Fatalf("Missing `name` for resource")
+		errs.Add("name", "Missing `name` for resource")
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		log.Fatalf("`is_list_of_ids: true` implies resource has exactly one `identity` property")
+		errs.Add("nested_query.is_list_of_ids", "`is_list_of_ids: true` implies resource has exactly one `identity` property")
 	}
 
 	// Ensures we have all properties defined
-	for _, i := range r.Identity {
+	for idx, i := range r.Identity {
 		hasIdentify := slices.ContainsFunc(r.AllUserProperties(), func(p *Type) bool {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			log.Fatalf("Missing property/parameter for identity %s", i)
+			errs.Add(fmt.Sprintf("identity.%d", idx), "Missing property/parameter for identity %s", i)
 		}
 	}
 
 	if r.Description == "" {
-		log.Fatalf("Missing `description` for resource %s", r.Name)
+		errs.Add("description", "Missing `description` for resource %s", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			log.Fatalf("Missing `properties` for resource %s", r.Name)
+			errs.Add("properties", "Missing `properties` for resource %s", r.Name)
 		}
 	}
 
 	allowed := []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.CreateVerb) {
-		log.Fatalf("Value on `create_verb` should be one of %#v", allowed)
+		errs.Add("create_verb", "Value on `create_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"GET", "POST"}
 	if !slices.Contains(allowed, r.ReadVerb) {
-		log.Fatalf("Value on `read_verb` should be one of %#v", allowed)
+		errs.Add("read_verb", "Value on `read_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
 	if !slices.Contains(allowed, r.DeleteVerb) {
-		log.Fatalf("Value on `delete_verb` should be one of %#v", allowed)
+		errs.Add("delete_verb", "Value on `delete_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.UpdateVerb) {
-		log.Fatalf("Value on `update_verb` should be one of %#v", allowed)
+		errs.Add("update_verb", "Value on `update_verb` should be one of %#v", allowed)
 	}
 
 	for _, property := range r.AllProperties() {
-		property.Validate(r.Name)
+		errs.Append(property.Validate(r.Name))
 	}
 
 	if r.IamPolicy != nil {
-		r.IamPolicy.Validate(r.Name)
+		errs.AppendErrors("iam_policy", r.IamPolicy.Validate(r.Name))
 	}
 
 	if r.NestedQuery != nil {
-		r.NestedQuery.Validate(r.Name)
+		errs.AppendErrors("nested_query", r.NestedQuery.Validate(r.Name))
+	}
+
+	if r.Datasource != nil {
+		errs.Append(r.validateDatasource().WithPathPrefix("datasource"))
+	}
+
+	if r.ListDatasource != nil {
+		errs.Append(r.validateListDatasource().WithPathPrefix("list_datasource"))
+	}
+
+	if r.ListResource != nil {
+		errs.Append(r.validateListResource().WithPathPrefix("list_resource"))
+	}
+
+	if r.Ephemeral != nil {
+		errs.Append(r.validateEphemeral().WithPathPrefix("ephemeral"))
+	}
+
+	if r.Framework {
+		errs.Append(r.validateFramework())
 	}
 
+	errs.Append(r.validateActions())
+
+	if r.DeletionProtection != nil {
+		errs.Append(r.validateDeletionProtection())
+	}
+
+	errs.Append(r.validateStateUpgrades())
+
+	errs.Append(r.validateUpdateGroups())
+
 	for _, example := range r.Examples {
-		example.Validate(r.Name)
+		errs.AppendErrors(joinYamlPath("examples", example.Name), example.Validate(r.Name))
 	}
 
 	if r.Async != nil {
-		r.Async.Validate()
+		errs.Append(r.Async.Validate().WithPathPrefix("async"))
+		if r.Async.Operation != nil && r.Async.Operation.Polling != nil && !r.AutogenAsync {
+			errs.Add("async.operation.polling", "`polling` can only be set on the operation of a resource with `autogen_async`, as other resources use handwritten operation waiters.")
+		}
 	}
+
+	return errs.InFile(r.SourceYamlFile)
 }
 
 // ====================
@@ -835,6 +936,16 @@ func (r Resource) RootLabels() bool {
 	return false
 }
 
+// Check if the resource has root "annotations" field
+func (r Resource) RootAnnotations() bool {
+	for _, p := range r.RootProperties() {
+		if p.IsA("KeyValueAnnotations") {
+			return true
+		}
+	}
+	return false
+}
+
 // Return labels fields that should be added to ImportStateVerifyIgnore
 func (r Resource) IgnoreReadLabelsFields(props []*Type) []string {
 	fields := make([]string, 0)
@@ -896,6 +1007,12 @@ func (r *Resource) ExcludeIfNotInVersion(version *product.Version) {
 			p.ExcludeIfNotInVersion(version)
 		}
 	}
+
+	for _, a := range r.Actions {
+		for _, p := range a.Properties {
+			p.ExcludeIfNotInVersion(version)
+		}
+	}
 }
 
 // ====================
@@ -1680,7 +1797,13 @@ func (r Resource) ResourceListKey() string {
 }
 
 func (r Resource) ListUrlTemplate() string {
-	return strings.Replace(r.CollectionUrl(), "zones/{{zone}}", "aggregated", 1)
+	return fmt.Sprintf("%s%s", r.ProductMetadata.BaseUrl, r.ListUri())
+}
+
+// Returns the partial uri used to list all instances of the resource. Zonal
+// resources are listed across all zones through their aggregated list.
+func (r Resource) ListUri() string {
+	return strings.Replace(r.collectionUri(), "zones/{{zone}}", "aggregated", 1)
 }
 
 func (r Resource) DeleteUrlTemplate() string {
@@ -1753,7 +1876,7 @@ func (r Resource) PropertiesByCustomUpdateGroups() []UpdateGroup {
 		}
 		return a.UpdateId < b.UpdateId
 	})
-	return updateGroups
+	return r.orderUpdateGroups(updateGroups)
 }
 
 func (r Resource) FieldSpecificUpdateMethods() bool {
@@ -1771,14 +1894,30 @@ func (r Resource) CustomUpdatePropertiesByKey(properties []*Type, updateUrl stri
 	})
 }
 
+// Returns the Terraform names of the properties, each followed by the
+// deprecated old name of a renamed property, to check them for changes.
 func (r Resource) PropertyNamesToStrings(properties []*Type) []string {
 	var propertyNames []string
 	for _, prop := range properties {
 		propertyNames = append(propertyNames, google.Underscore(prop.Name))
+		if alias := prop.RenameAlias(); alias != "" {
+			propertyNames = append(propertyNames, alias)
+		}
 	}
 	return propertyNames
 }
 
+// Returns the deprecated old name of the top-level property with the given
+// Terraform name, if it was renamed.
+func (r Resource) RenamedFromName(name string) string {
+	for _, prop := range r.AllUserProperties() {
+		if google.Underscore(prop.Name) == name {
+			return prop.RenameAlias()
+		}
+	}
+	return ""
+}
+
 func (r Resource) IsExcluded() bool {
 	return r.Exclude || r.ExcludeResource
 }
@@ -2022,6 +2161,694 @@ func (r Resource) MarkdownHeader(templatePath string) string {
 	return strings.Replace(r.CodeHeader(templatePath), "//", "#", -1)
 }
 
+// ====================
+// Datasource Methods
+// ====================
+// Returns true if a data source should be generated for the resource. The
+// data source reads through the resource, so the resource must be generated.
+func (r Resource) GenerateDatasource() bool {
+	return r.Datasource != nil && r.Datasource.Generate && !r.IsExcluded() && !r.ExcludeRead
+}
+
+// Returns the name of the function returning the data source's schema.Resource
+func (r Resource) DatasourceName() string {
+	return fmt.Sprintf("DataSource%s", r.ResourceName())
+}
+
+// Returns the fields users set to identify the instance a data source reads.
+// Unless configured, these are the fields of the id format other than the
+// ones that default to the provider configuration.
+func (r Resource) DatasourceRequiredFields() []string {
+	if r.Datasource != nil && len(r.Datasource.RequiredFields) > 0 {
+		return r.Datasource.RequiredFields
+	}
+
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.GetIdFormat()) {
+		if !slices.Contains(providerPlaceholders, f) && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the fields of the id format that users may set on a data source,
+// which aren't required. Fields missing from the resource's schema are left
+// out, as they can't be set.
+func (r Resource) DatasourceOptionalFields() []string {
+	required := r.DatasourceRequiredFields()
+
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.GetIdFormat()) {
+		if slices.Contains(required, f) || slices.Contains(fields, f) {
+			continue
+		}
+		if (f == "project" && r.HasProject()) || r.hasSchemaField(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the first sentence of the description of a top-level field, for
+// the data source documentation
+func (r Resource) DatasourceFieldDescription(name string) string {
+	if field := r.schemaField(name); field != nil {
+		return strings.Join(strings.Fields(google.FirstSentence(field.Description)), " ")
+	}
+	return ""
+}
+
+// Returns true if name is a top-level field of the resource's schema
+func (r Resource) hasSchemaField(name string) bool {
+	return r.schemaField(name) != nil
+}
+
+func (r Resource) schemaField(name string) *Type {
+	for _, p := range google.Concat(r.RootProperties(), r.UserVirtualFields()) {
+		if google.Underscore(p.Name) == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (r Resource) validateDatasource() ValidationErrors {
+	var errs ValidationErrors
+
+	if r.Datasource.Generate && (r.ExcludeResource || r.ExcludeRead) {
+		errs.Add("generate", "Cannot generate a data source for resource %s, as its resource or read isn't generated", r.Name)
+	}
+
+	for i, f := range r.Datasource.RequiredFields {
+		if !r.hasSchemaField(f) && !(f == "project" && r.HasProject()) {
+			errs.Add(fmt.Sprintf("required_fields.%d", i), "Required field `%s` does not match any property or parameter of resource %s", f, r.Name)
+		}
+	}
+
+	return errs
+}
+
+// Returns true if a list data source should be generated for the resource.
+// Each listed instance is flattened with the resource's flatteners, so the
+// resource must be generated.
+func (r Resource) GenerateListDatasource() bool {
+	return r.ListDatasource != nil && r.ListDatasource.Generate && !r.IsExcluded() && !r.ExcludeRead && r.NestedQuery == nil
+}
+
+// Returns the name of the function returning the list data source's
+// schema.Resource
+func (r Resource) ListDatasourceName() string {
+	return fmt.Sprintf("DataSource%s", google.Plural(r.ResourceName()))
+}
+
+// Returns the Terraform name of the list data source, e.g. google_pubsub_topics
+func (r Resource) ListDatasourceTerraformName() string {
+	return google.Plural(r.TerraformName())
+}
+
+// Returns the name of the list data source's field holding the instances
+func (r Resource) ListDatasourceItemsField() string {
+	return google.Underscore(google.Plural(r.Name))
+}
+
+// Returns the fields of the base URL that users must set on a list data
+// source to identify the parent to list instances under.
+func (r Resource) ListDatasourceRequiredFields() []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.BaseUrl) {
+		if !slices.Contains(providerPlaceholders, f) && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the fields of the base URL that default to the provider
+// configuration, which users may set on a list data source.
+func (r Resource) ListDatasourceOptionalFields() []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.BaseUrl) {
+		if !slices.Contains(providerPlaceholders, f) || slices.Contains(fields, f) {
+			continue
+		}
+		if (f == "project" && r.HasProject()) || r.hasSchemaField(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the fields of the base URL that are part of the resource's schema
+// but aren't read from the API, such as url_param_only parameters. Listed
+// instances take their values from the list data source.
+func (r Resource) ListDatasourceParentFields() []string {
+	var fields []string
+	for _, f := range google.Concat(r.ListDatasourceRequiredFields(), r.ListDatasourceOptionalFields()) {
+		if !r.hasSchemaField(f) && !(f == "project" && r.HasProject()) {
+			continue
+		}
+		read := slices.ContainsFunc(r.ReadProperties(), func(p *Type) bool {
+			return google.Underscore(p.Name) == f
+		})
+		if !read {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the name of the flattener used for a top-level property of an
+// instance returned outside of the resource's read, such as by the list
+// method. Flatteners of labels and annotations only keep the keys configured
+// on the resource, which these instances have none of, so all of the keys are
+// kept instead.
+func (r Resource) ItemFlattener(p *Type) string {
+	name := p.Name
+	switch {
+	case p.IsA("KeyValueLabels") || p.IsA("KeyValueTerraformLabels"):
+		name = "effectiveLabels"
+	case p.IsA("KeyValueAnnotations"):
+		name = "effectiveAnnotations"
+	}
+	return fmt.Sprintf("flatten%s%s", r.ResourceName(), google.Camelize(name, "upper"))
+}
+
+func (r Resource) validateListDatasource() ValidationErrors {
+	var errs ValidationErrors
+
+	if !r.ListDatasource.Generate {
+		return errs
+	}
+	if r.ExcludeResource || r.ExcludeRead {
+		errs.Add("generate", "Cannot generate a list data source for resource %s, as its resource or read isn't generated", r.Name)
+	}
+	if r.NestedQuery != nil {
+		errs.Add("generate", "Cannot generate a list data source for resource %s, as it is read through nested_query", r.Name)
+	}
+
+	return errs
+}
+
+// ====================
+// List Resource Methods
+// ====================
+// Returns true if a list resource should be generated for the resource. Its
+// results are flattened with the resource's flatteners and carry its identity.
+func (r Resource) GenerateListResource() bool {
+	return r.ListResource != nil && r.ListResource.Generate && !r.IsExcluded() && !r.ExcludeRead && r.NestedQuery == nil
+}
+
+// Returns the name of the function returning the list resource
+func (r Resource) ListResourceName() string {
+	return fmt.Sprintf("New%sListResource", r.ResourceName())
+}
+
+// Returns true if the resource is listed across all zones through its
+// aggregated list, in which case the zone of each instance comes from the
+// listed object.
+func (r Resource) IsAggregatedList() bool {
+	return strings.Contains(r.ListUri(), "/aggregated/")
+}
+
+// Returns the fields of the list URL that users must set in a list block
+func (r Resource) ListResourceRequiredFields() []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.ListUri()) {
+		if !slices.Contains(providerPlaceholders, f) && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the fields of the list URL that default to the provider
+// configuration, which users may set in a list block.
+func (r Resource) ListResourceOptionalFields() []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.ListUri()) {
+		if slices.Contains(providerPlaceholders, f) && f != "universe_domain" && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns all fields of the list URL that users may set in a list block
+func (r Resource) ListResourceConfigFields() []string {
+	return google.Concat(r.ListResourceRequiredFields(), r.ListResourceOptionalFields())
+}
+
+// Returns the fields of the list URL that are part of the resource's schema
+// but aren't read from the API. Listed instances take their values from the
+// list block.
+func (r Resource) ListResourceParentFields() []string {
+	var fields []string
+	for _, f := range r.ListResourceConfigFields() {
+		if !r.hasSchemaField(f) && !(f == "project" && r.HasProject()) {
+			continue
+		}
+		read := slices.ContainsFunc(r.ReadProperties(), func(p *Type) bool {
+			return google.Underscore(p.Name) == f
+		})
+		if !read {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (r Resource) validateListResource() ValidationErrors {
+	var errs ValidationErrors
+
+	if !r.ListResource.Generate {
+		return errs
+	}
+	if r.ExcludeResource || r.ExcludeRead {
+		errs.Add("generate", "Cannot generate a list resource for resource %s, as its resource or read isn't generated", r.Name)
+	}
+	if r.NestedQuery != nil {
+		errs.Add("generate", "Cannot generate a list resource for resource %s, as it is read through nested_query", r.Name)
+	}
+	if len(r.ResourceIdentityFields()) == 0 {
+		errs.Add("generate", "Cannot generate a list resource for resource %s, as none of the fields of its id format are part of its schema", r.Name)
+	}
+
+	return errs
+}
+
+// Ephemeral Resource Methods
+// ====================
+// Returns true if an ephemeral resource should be generated for the resource.
+// Unlike other generated types, it doesn't need the resource to be generated.
+func (r Resource) GenerateEphemeral() bool {
+	return r.Ephemeral != nil && r.Ephemeral.Generate && !r.Exclude
+}
+
+// Returns the name of the function returning the ephemeral resource
+func (r Resource) EphemeralName() string {
+	return fmt.Sprintf("New%sEphemeralResource", r.ResourceName())
+}
+
+// Returns the url the ephemeral resource is opened with
+func (r Resource) EphemeralOpenUri() string {
+	return ephemeralCallUri(r.Ephemeral.Open, r.SelfLinkUri())
+}
+
+func (r Resource) EphemeralOpenVerb() string {
+	return ephemeralCallVerb(r.Ephemeral.Open, "GET")
+}
+
+func (r Resource) EphemeralRenewUri() string {
+	return ephemeralCallUri(r.Ephemeral.Renew, r.SelfLinkUri())
+}
+
+func (r Resource) EphemeralRenewVerb() string {
+	return ephemeralCallVerb(r.Ephemeral.Renew, "POST")
+}
+
+func (r Resource) EphemeralCloseUri() string {
+	return ephemeralCallUri(r.Ephemeral.Close, r.SelfLinkUri())
+}
+
+func (r Resource) EphemeralCloseVerb() string {
+	return ephemeralCallVerb(r.Ephemeral.Close, "DELETE")
+}
+
+func ephemeralCallUri(c *resource.EphemeralCall, defaultUri string) string {
+	if c == nil || c.Url == "" {
+		return defaultUri
+	}
+	return c.Url
+}
+
+func ephemeralCallVerb(c *resource.EphemeralCall, defaultVerb string) string {
+	if c == nil || c.Verb == "" {
+		return defaultVerb
+	}
+	return c.Verb
+}
+
+// Returns the fields of the open URL that users must set on the ephemeral
+// resource
+func (r Resource) EphemeralRequiredFields() []string {
+	return r.requiredUrlFields(r.EphemeralOpenUri())
+}
+
+// Returns the fields of the open URL that default to the provider
+// configuration or to their default_value, which users may set on the
+// ephemeral resource.
+func (r Resource) EphemeralOptionalFields() []string {
+	return r.optionalUrlFields(r.EphemeralOpenUri())
+}
+
+// Returns all fields of the open URL that users may set on the ephemeral
+// resource
+func (r Resource) EphemeralConfigFields() []string {
+	return google.Concat(r.EphemeralRequiredFields(), r.EphemeralOptionalFields())
+}
+
+// Returns the Go literal of the default_value of a field of the ephemeral
+// resource, or an empty string if it has none.
+func (r Resource) EphemeralFieldDefault(name string) string {
+	if field := r.schemaField(name); field != nil && field.DefaultValue != nil {
+		return field.GoLiteral(field.DefaultValue)
+	}
+	return ""
+}
+
+func (r Resource) isOptionalUrlField(name string) bool {
+	return slices.Contains(providerPlaceholders, name) || r.EphemeralFieldDefault(name) != ""
+}
+
+// Returns the fields of url that users must set on generated types that are
+// configured with the fields of a URL, such as ephemeral resources.
+func (r Resource) requiredUrlFields(url string) []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(url) {
+		if !r.isOptionalUrlField(f) && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns the fields of url that default to the provider configuration or to
+// their default_value on generated types that are configured with the fields
+// of a URL.
+func (r Resource) optionalUrlFields(url string) []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(url) {
+		if r.isOptionalUrlField(f) && f != "universe_domain" && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (r Resource) validateEphemeral() ValidationErrors {
+	var errs ValidationErrors
+
+	if !r.Ephemeral.Generate {
+		return errs
+	}
+	if r.Exclude {
+		errs.Add("generate", "Cannot generate an ephemeral resource for resource %s, as it is excluded; read-only resources should set exclude_resource instead", r.Name)
+	}
+	if r.NestedQuery != nil {
+		errs.Add("generate", "Cannot generate an ephemeral resource for resource %s, as it is read through nested_query", r.Name)
+	}
+	for _, f := range r.EphemeralConfigFields() {
+		if !r.hasSchemaField(f) && !(f == "project" && r.HasProject()) {
+			errs.Add("open.url", "Field %s of the open URL of resource %s isn't part of its schema", f, r.Name)
+		}
+	}
+	if r.Ephemeral.Renew != nil && r.Ephemeral.RenewAfterMinutes <= 0 {
+		errs.Add("renew_after_minutes", "Resource %s must set renew_after_minutes to renew its ephemeral resource", r.Name)
+	}
+
+	return errs
+}
+
+// Framework Resource Methods
+// ====================
+// Returns true if the resource is generated as a plugin framework resource.
+// Its SDK resource is still generated, but only runs its CRUD functions for
+// the framework resource through fwresource.SDKResource.
+func (r Resource) GenerateFrameworkResource() bool {
+	return r.Framework && !r.IsExcluded()
+}
+
+// Returns the name of the function returning the framework resource
+func (r Resource) FrameworkResourceName() string {
+	return fmt.Sprintf("New%sResource", r.ResourceName())
+}
+
+// Returns the fields the framework resource defaults to the provider
+// configuration when planning, in place of the default CustomizeDiff of the
+// SDK resource, mapped to whether changing them replaces the resource.
+func (r Resource) FrameworkProviderDefaults() map[string]bool {
+	defaults := make(map[string]bool)
+	if r.ExcludeDefaultCdiff {
+		return defaults
+	}
+	if r.HasProject() {
+		defaults["project"] = true
+	}
+	for _, f := range []string{"region", "zone"} {
+		if (f == "region" && !r.HasRegion()) || (f == "zone" && !r.HasZone()) {
+			continue
+		}
+		if field := r.schemaField(f); field != nil {
+			defaults[f] = field.IsForceNew()
+		}
+	}
+	return defaults
+}
+
+// Returns true if a field is defaulted to the provider configuration by the
+// framework resource, in which case its replacement is planned there rather
+// than by a plan modifier.
+func (r Resource) IsFrameworkProviderDefault(name string) bool {
+	_, ok := r.FrameworkProviderDefaults()[name]
+	return ok
+}
+
+// Features of the SDK that generated framework resources don't support yet,
+// as they are implemented with functions of the SDK's schema or diff.
+func (r Resource) validateFramework() ValidationErrors {
+	var errs ValidationErrors
+
+	if len(r.CustomDiff) > 0 {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as it uses custom_diff or labels", r.Name)
+	}
+	if len(r.UnorderedListProperties()) > 0 {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as it has unordered lists", r.Name)
+	}
+	if r.StateUpgraders || len(r.StateUpgradeSteps()) > 0 {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as it has state upgraders", r.Name)
+	}
+	if r.CustomCode.ExtraSchemaEntry != "" || r.CustomCode.ValidateRawResourceConfigFuncs != "" {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as it customizes its SDK schema", r.Name)
+	}
+	if r.DeletionProtection != nil {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as it uses deletion_protection", r.Name)
+	}
+	if r.GenerateListResource() {
+		errs.Add("framework", "Resource %s can't be generated for the plugin framework, as list resources are generated for SDK resources", r.Name)
+	}
+	for _, p := range google.Concat(r.AllUserProperties(), r.VirtualFields) {
+		errs.Append(p.validateFramework())
+	}
+
+	return errs
+}
+
+// Deletion Protection Methods
+// ====================
+// Returns the name of the field that protects the resource from deletion.
+func (r Resource) DeletionProtectionField() string {
+	if r.DeletionProtection == nil {
+		return ""
+	}
+	if r.DeletionProtection.ApiField != "" {
+		return google.Underscore(r.DeletionProtection.ApiField)
+	}
+	return "deletion_protection"
+}
+
+// Adds the generated deletion_protection virtual field, unless the guard is
+// backed by an API field.
+func (r *Resource) addDeletionProtectionField() {
+	if r.DeletionProtection == nil || r.DeletionProtection.ApiField != "" {
+		return
+	}
+
+	name := strings.ToLower(google.SpaceSeparated(r.Name))
+	description := fmt.Sprintf("Whether Terraform will be prevented from destroying the %s. Defaults to `%t`.\n", name, r.DeletionProtection.Default)
+	description += fmt.Sprintf("When the field is set to true in Terraform state, a `terraform apply` or\n`terraform destroy` that would delete the %s will fail.", name)
+	if r.DeletionProtection.BlockReplacement {
+		description += fmt.Sprintf(" Plans that would\nreplace the %s fail as well.", name)
+	}
+	description += fmt.Sprintf("\nWhen the field is set to false, deleting the %s is allowed.\n", name)
+
+	r.VirtualFields = append(r.VirtualFields, &Type{
+		Name:         "deletion_protection",
+		Type:         "Boolean",
+		Description:  description,
+		DefaultValue: r.DeletionProtection.Default,
+	})
+}
+
+// Has the sweeper disable the API field that protects the resource from
+// deletion before deleting resources. The property itself is left as
+// declared, as adding a default to an existing field is a breaking change.
+func (r *Resource) setDeletionProtectionDefaults() {
+	if r.DeletionProtection == nil || r.DeletionProtection.ApiField == "" {
+		return
+	}
+
+	p := r.deletionProtectionProperty()
+	if p == nil {
+		return
+	}
+	if r.Sweeper.EnsureValue == nil && r.ShouldGenerateSweepers() {
+		r.Sweeper.EnsureValue = &resource.EnsureValue{
+			Field: p.ApiName,
+			Value: "false",
+		}
+	}
+}
+
+func (r Resource) deletionProtectionProperty() *Type {
+	for _, p := range r.RootProperties() {
+		if p.Name == r.DeletionProtection.ApiField {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the CustomizeDiff function that fails plans replacing a protected
+// resource, or "" if replacement isn't blocked.
+func (r Resource) DeletionProtectionDiff() string {
+	if r.DeletionProtection == nil || !r.DeletionProtection.BlockReplacement {
+		return ""
+	}
+
+	fields := []string{fmt.Sprintf("%q", r.DeletionProtectionField())}
+	if r.HasProject() {
+		fields = append(fields, `"project"`)
+	}
+	for _, f := range forceNewFieldPaths(google.Concat(r.RootProperties(), r.VirtualFields), "") {
+		fields = append(fields, fmt.Sprintf("%q", f))
+	}
+	return fmt.Sprintf("tpgresource.DeletionProtectionDiff(%s)", strings.Join(fields, ", "))
+}
+
+// Returns the dot-separated paths of the fields whose changes force the
+// resource to be replaced, without descending into the fields of force new
+// objects.
+func forceNewFieldPaths(props []*Type, prefix string) []string {
+	var paths []string
+	for _, p := range props {
+		path := prefix + google.Underscore(p.Name)
+		switch {
+		case p.IsForceNew():
+			paths = append(paths, path)
+		case p.IsA("NestedObject"):
+			paths = append(paths, forceNewFieldPaths(p.RootProperties(), path+".")...)
+		case p.IsA("Array") && p.ItemType.IsA("NestedObject"):
+			paths = append(paths, forceNewFieldPaths(p.ItemType.RootProperties(), path+".")...)
+		}
+	}
+	return paths
+}
+
+// Returns the note added to the documentation of a protected resource.
+func (r Resource) DeletionProtectionNote() string {
+	if r.DeletionProtection == nil {
+		return ""
+	}
+
+	state := "disabled"
+	if r.DeletionProtection.Default {
+		state = "enabled"
+	}
+	action := "delete"
+	if r.DeletionProtection.BlockReplacement {
+		action = "delete or replace"
+	}
+	return fmt.Sprintf("Deletion protection is %s by default for this resource. Terraform fails to %s\n"+
+		"the resource while `%s` is `true` in Terraform state: set it to `false` and run\n"+
+		"`terraform apply` first.", state, action, r.DeletionProtectionField())
+}
+
+// Returns true if the test of an example checks that the resource is protected
+// from deletion, which it does for examples whose tests override the field.
+func (r Resource) TestsDeletionProtection(e resource.Examples) bool {
+	if r.DeletionProtection == nil {
+		return false
+	}
+	_, ok := e.TestVarsOverrides[r.DeletionProtectionField()]
+	return ok
+}
+
+// Returns true if any of the tests of the resource checks that it is protected
+// from deletion.
+func (r Resource) HasDeletionProtectionTests() bool {
+	return slices.ContainsFunc(r.TestExamples(), r.TestsDeletionProtection)
+}
+
+func (r Resource) validateDeletionProtection() ValidationErrors {
+	var errs ValidationErrors
+
+	if r.DeletionProtection.ApiField == "" {
+		declared := google.Select(r.VirtualFields, func(p *Type) bool {
+			return p.Name == "deletion_protection"
+		})
+		if len(declared) > 1 {
+			errs.Add("virtual_fields", "Virtual field deletion_protection is generated for resource %s by `deletion_protection`, and shouldn't be declared", r.Name)
+		}
+		return errs
+	}
+
+	if r.DeletionProtection.Default {
+		errs.Add("deletion_protection.default", "`default` only applies to the generated deletion_protection field, set `default_value` on property %s instead", r.DeletionProtection.ApiField)
+	}
+
+	p := r.deletionProtectionProperty()
+	if p == nil {
+		errs.Add("deletion_protection.api_field", "Missing top-level property %s for `deletion_protection`", r.DeletionProtection.ApiField)
+	} else if !p.IsA("Boolean") || p.Output {
+		errs.Add("deletion_protection.api_field", "Property %s for `deletion_protection` should be a Boolean that isn't output only", p.Name)
+	}
+
+	return errs
+}
+
+// Identity Methods
+// ====================
+// Returns true if the resource has an identity, which it sets when it is read
+// and can be imported by.
+func (r Resource) HasResourceIdentity() bool {
+	return !r.ExcludeRead && len(r.ResourceIdentityFields()) > 0
+}
+
+// Returns the fields of the resource's identity, which are the fields of its
+// id format that are part of its schema. Fields that default to the provider
+// configuration are optional when importing by identity.
+func (r Resource) ResourceIdentityFields() []string {
+	var fields []string
+	for _, f := range r.ExtractIdentifiers(r.GetIdFormat()) {
+		if slices.Contains(fields, f) {
+			continue
+		}
+		if (f == "project" && r.HasProject()) || r.hasSchemaField(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Returns true if an identity field may be left unset when importing by
+// identity, as it defaults to the provider configuration.
+func (r Resource) IsOptionalIdentityField(name string) bool {
+	return slices.Contains(providerPlaceholders, name)
+}
+
+// Returns true if a field of the resource's identity can be updated in place,
+// in which case its identity may change after it is created. Output fields
+// are set by the API and don't change.
+func (r Resource) HasMutableIdentity() bool {
+	for _, f := range r.ResourceIdentityFields() {
+		if field := r.schemaField(f); field != nil && !field.Output && !field.IsForceNew() {
+			return true
+		}
+	}
+	return false
+}
+
 // TGC Methods
 // ====================
 // Lists fields that test.BidirectionalConversion should ignore
@@ -0,0 +1,53 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Configuration of a data source generated from the resource, which reads a
+// single existing instance of it by its identifying fields. The data source
+// exposes the resource's schema as computed fields and reuses its read.
+type Datasource struct {
+	// boolean of if the data source should be generated
+	Generate bool
+
+	// The fields users set to identify the instance to read.
+	// Defaults to the fields in the resource's id_format, other than
+	// provider-level fields such as project and region. Fields of the
+	// id_format that aren't required are optional.
+	RequiredFields []string `yaml:"required_fields"`
+
+	// Custom strings inserted into the data source documentation, in the
+	// same sections as for the resource documentation.
+	Docs Docs
+}
+
+// Configuration of a data source generated from the resource, which lists all
+// instances of it under the parent in its base_url. Pages of results are
+// followed until the list is exhausted, and each instance is flattened the
+// same way the resource reads it.
+type ListDatasource struct {
+	// boolean of if the list data source should be generated
+	Generate bool
+
+	// If true, the list method supports a server-side `filter` query
+	// parameter, which is exposed as an optional `filter` field.
+	Filter bool
+
+	// If true, the list method supports an `orderBy` query parameter, which
+	// is exposed as an optional `order_by` field.
+	OrderBy bool `yaml:"order_by"`
+
+	// Custom strings inserted into the data source documentation, in the
+	// same sections as for the resource documentation.
+	Docs Docs
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// DeletionProtection configures a guard that prevents Terraform from deleting
+// a resource while protection is enabled in Terraform state. The generator
+// adds the `deletion_protection` field (unless ApiField is set), the check in
+// the resource's Delete function, a note in the resource documentation and
+// acceptance test steps for examples that override the field in tests.
+type DeletionProtection struct {
+	// Default is the value of the generated field when it isn't set in
+	// configuration.
+	Default bool `yaml:"default"`
+
+	// ApiField is the name of an API field that protects the resource from
+	// deletion, such as "deletionProtection". When set, no virtual field is
+	// generated: the guard reads the property instead, and the sweeper sets the
+	// field to false before deleting resources, unless sweeper.ensure_value is
+	// configured. The property keeps its own default, so Default can't be set.
+	// Only top-level Boolean properties are supported.
+	ApiField string `yaml:"api_field,omitempty"`
+
+	// BlockReplacement also fails plans that would replace the resource, by
+	// changing an immutable field, while protection is enabled in state.
+	BlockReplacement bool `yaml:"block_replacement,omitempty"`
+}
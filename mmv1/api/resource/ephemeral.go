@@ -0,0 +1,59 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Configuration of a Terraform ephemeral resource generated for the resource,
+// which returns values such as secrets or short-lived credentials without
+// storing them in state. Ephemeral resources are implemented with the plugin
+// framework. The object returned when the ephemeral resource is opened is
+// flattened the same way the resource reads it, and the fields of the URL it
+// is opened with are its arguments.
+//
+// Ephemeral resources can be generated for read-only resources that use
+// `exclude_resource` instead of `exclude`, in which case the resource's schema
+// and flatteners are generated along with the ephemeral resource.
+type Ephemeral struct {
+	// boolean of if the ephemeral resource should be generated
+	Generate bool
+
+	// The API call made when the ephemeral resource is opened, whose
+	// response is the ephemeral resource's result. Defaults to reading the
+	// resource through its self_link.
+	Open *EphemeralCall
+
+	// The API call made to renew the result of the ephemeral resource, such
+	// as to extend the lifetime of a credential, every
+	// `renew_after_minutes` while it is in use. Not renewed by default.
+	Renew *EphemeralCall
+
+	// The API call made when the ephemeral resource is closed, such as to
+	// revoke a credential. Nothing is called by default.
+	Close *EphemeralCall
+
+	// The number of minutes after which the result is renewed. Required if
+	// renew is set.
+	RenewAfterMinutes int `yaml:"renew_after_minutes,omitempty"`
+}
+
+// An API call made by an ephemeral resource
+type EphemeralCall struct {
+	// The URL of the call, relative to the product's base_url, with the
+	// same templating as the resource's URLs. URLs of renew and close can
+	// use fields of the result. Defaults to the resource's self_link.
+	Url string
+
+	// The HTTP verb of the call. Defaults to GET when opening, POST when
+	// renewing and DELETE when closing.
+	Verb string
+}
@@ -212,11 +212,15 @@ func (e *Examples) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (e *Examples) Validate(rName string) {
+func (e *Examples) Validate(rName string) []error {
+	var errs []error
 	if e.Name == "" {
-		log.Fatalf("Missing `name` for one example in resource %s", rName)
+		errs = append(errs, fmt.Errorf("Missing `name` for one example in resource %s", rName))
 	}
-	e.ValidateExternalProviders()
+	if err := e.ValidateExternalProviders(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
 }
 
 func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) {
@@ -235,7 +239,7 @@ func validateRegexForContents(r *regexp.Regexp, contents string, configPath stri
 	}
 }
 
-func (e *Examples) ValidateExternalProviders() {
+func (e *Examples) ValidateExternalProviders() error {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -251,8 +255,9 @@ func (e *Examples) ValidateExternalProviders() {
 	}
 
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		return fmt.Errorf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
+	return nil
 }
 
 // Executes example templates for documentation and tests
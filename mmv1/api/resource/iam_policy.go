@@ -14,7 +14,7 @@
 package resource
 
 import (
-	"log"
+	"fmt"
 	"slices"
 )
 
@@ -139,19 +139,23 @@ func (p *IamPolicy) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *IamPolicy) Validate(rName string) {
+func (p *IamPolicy) Validate(rName string) []error {
+	var errs []error
+
 	allowed := []string{"GET", "POST"}
 	if !slices.Contains(allowed, p.FetchIamPolicyVerb) {
-		log.Fatalf("Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs = append(errs, fmt.Errorf("Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName))
 	}
 
 	allowed = []string{"POST", "PUT"}
 	if !slices.Contains(allowed, p.SetIamPolicyVerb) {
-		log.Fatalf("Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs = append(errs, fmt.Errorf("Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName))
 	}
 
 	allowed = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
 	if p.IamConditionsRequestType != "" && !slices.Contains(allowed, p.IamConditionsRequestType) {
-		log.Fatalf("Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)
+		errs = append(errs, fmt.Errorf("Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName))
 	}
+
+	return errs
 }
@@ -0,0 +1,28 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Configuration of a Terraform list resource generated for the resource, which
+// lets `list` blocks in query files discover existing instances of it so they
+// can be imported. List resources are implemented with the plugin framework.
+// Each listed instance is returned with the resource's identity, which is
+// generated along with it.
+type ListResource struct {
+	// boolean of if the list resource should be generated
+	Generate bool
+
+	// If true, the list method supports a server-side `filter` query
+	// parameter, which is exposed as an optional `filter` argument.
+	Filter bool
+}
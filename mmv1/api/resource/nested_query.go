@@ -13,7 +13,7 @@
 
 package resource
 
-import "log"
+import "fmt"
 
 // Metadata for resources that are nested within a parent resource, as
 // a list of resources or single object within the parent.
@@ -43,8 +43,9 @@ type NestedQuery struct {
 	ModifyByPatch bool `yaml:"modify_by_patch"`
 }
 
-func (q *NestedQuery) Validate(rName string) {
+func (q *NestedQuery) Validate(rName string) []error {
 	if len(q.Keys) == 0 {
-		log.Fatalf("Missing `keys` for `nested_query` in resource %s", rName)
+		return []error{fmt.Errorf("Missing `keys` for `nested_query` in resource %s", rName)}
 	}
+	return nil
 }
@@ -0,0 +1,88 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// A record of the Terraform schema of a resource as it was released in a
+// major version of the provider. Snapshots are recorded with
+// `--record-schema-snapshots` into
+// `products/<product>/snapshots/<version>/<resource>.v<major>.yaml`.
+//
+// The generator compares consecutive snapshots of a resource, and its
+// current schema, to generate the state upgraders of the resource when its
+// schema_version is increased.
+type SchemaSnapshot struct {
+	// The major version of the provider the snapshot was recorded for.
+	ProviderMajorVersion int `yaml:"provider_major_version"`
+
+	// The schema_version of the resource when the snapshot was recorded.
+	SchemaVersion int `yaml:"schema_version"`
+
+	// The top-level fields of the resource, sorted by name.
+	Fields []*SnapshotField
+}
+
+// A field of a schema snapshot, with the parts of the SDK schema that
+// determine the shape of its state.
+type SnapshotField struct {
+	Name string
+
+	// The SDK type of the field without its `schema.Type` prefix: String,
+	// Int, Float, Bool, List, Set or Map.
+	Type string
+
+	Required bool `yaml:"required,omitempty"`
+	Optional bool `yaml:"optional,omitempty"`
+	Computed bool `yaml:"computed,omitempty"`
+
+	MaxItems int `yaml:"max_items,omitempty"`
+
+	// The type of the elements of a List, Set or Map of primitive values.
+	Elem string `yaml:"elem,omitempty"`
+
+	// The fields of the elements of a List or Set of objects, sorted by name.
+	Fields []*SnapshotField `yaml:"fields,omitempty"`
+}
+
+// Returns true if the field is a collection of objects.
+func (f SnapshotField) IsObject() bool {
+	return len(f.Fields) > 0
+}
+
+// Returns true if the field holds a single object, whose fields are
+// addressed with the index 0 in state paths.
+func (f SnapshotField) IsSingleObject() bool {
+	return f.IsObject() && f.MaxItems == 1
+}
+
+// Returns true if the values of two fields have the same shape in state,
+// ignoring whether they are required, optional or computed. Lists and sets
+// have the same shape, as both are stored as arrays.
+func (f SnapshotField) SameShape(other SnapshotField) bool {
+	if f.stateType() != other.stateType() || f.Elem != other.Elem || len(f.Fields) != len(other.Fields) {
+		return false
+	}
+	for i, field := range f.Fields {
+		if field.Name != other.Fields[i].Name || !field.SameShape(*other.Fields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f SnapshotField) stateType() string {
+	if f.Type == "Set" {
+		return "List"
+	}
+	return f.Type
+}
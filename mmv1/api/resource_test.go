@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -503,3 +505,707 @@ func TestHasPostCreateComputedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceDatasourceFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		required    []string
+		optional    []string
+	}{
+		{
+			description: "defaults to the id format",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/widgets",
+				IdFormat: "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+				Parameters: []*Type{
+					{Name: "location"},
+				},
+			},
+			required: []string{"location", "name"},
+			optional: []string{"project"},
+		},
+		{
+			description: "provider fields missing from the schema are left out",
+			resource: Resource{
+				BaseUrl:  "organizations/{{organization}}/widgets",
+				IdFormat: "{{region}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+			},
+			required: []string{"name"},
+		},
+		{
+			description: "configured required fields",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/regions/{{region}}/widgets",
+				IdFormat: "projects/{{project}}/regions/{{region}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+				Parameters: []*Type{
+					{Name: "region"},
+				},
+				Datasource: &resource.Datasource{
+					Generate:       true,
+					RequiredFields: []string{"name", "region"},
+				},
+			},
+			required: []string{"name", "region"},
+			optional: []string{"project"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.resource.DatasourceRequiredFields(), tc.required; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected required fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.DatasourceOptionalFields(), tc.optional; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected optional fields %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceListDatasourceFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		required    []string
+		optional    []string
+		parent      []string
+	}{
+		{
+			description: "parent fields from the base url",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/widgets",
+				IdFormat: "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+				Parameters: []*Type{
+					{Name: "location", UrlParamOnly: true},
+				},
+			},
+			required: []string{"location"},
+			optional: []string{"project"},
+			parent:   []string{"location", "project"},
+		},
+		{
+			description: "fields read from the api aren't parent fields",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "{{parent}}/widgets",
+				Properties: []*Type{
+					{Name: "name"},
+					{Name: "parent"},
+				},
+			},
+			required: []string{"parent"},
+		},
+		{
+			description: "provider fields missing from the schema are left out",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "regions/{{region}}/widgets",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.resource.ListDatasourceRequiredFields(), tc.required; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected required fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.ListDatasourceOptionalFields(), tc.optional; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected optional fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.ListDatasourceParentFields(), tc.parent; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected parent fields %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceListResourceFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		uri         string
+		required    []string
+		optional    []string
+		parent      []string
+		identity    []string
+	}{
+		{
+			description: "regional resource",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/widgets",
+				IdFormat: "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+				Parameters: []*Type{
+					{Name: "location", UrlParamOnly: true},
+				},
+			},
+			uri:      "projects/{{project}}/locations/{{location}}/widgets",
+			required: []string{"location"},
+			optional: []string{"project"},
+			parent:   []string{"location", "project"},
+			identity: []string{"project", "location", "name"},
+		},
+		{
+			description: "zonal resource is listed through the aggregated list",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/zones/{{zone}}/widgets",
+				IdFormat: "projects/{{project}}/zones/{{zone}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+					{Name: "zone"},
+				},
+			},
+			uri:      "projects/{{project}}/aggregated/widgets",
+			optional: []string{"project"},
+			parent:   []string{"project"},
+			identity: []string{"project", "zone", "name"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.resource.ListUri(), tc.uri; got != want {
+				t.Errorf("expected list uri %q to be %q", got, want)
+			}
+			if got, want := tc.resource.ListResourceRequiredFields(), tc.required; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected required fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.ListResourceOptionalFields(), tc.optional; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected optional fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.ListResourceParentFields(), tc.parent; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected parent fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.ResourceIdentityFields(), tc.identity; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected identity fields %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceIdentity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		identity    bool
+		mutable     bool
+	}{
+		{
+			description: "immutable identity",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/widgets",
+				IdFormat: "projects/{{project}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Immutable: true},
+				},
+			},
+			identity: true,
+		},
+		{
+			description: "identity field updated in place",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/widgets",
+				IdFormat: "projects/{{project}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name"},
+				},
+			},
+			identity: true,
+			mutable:  true,
+		},
+		{
+			description: "identity field set by the api",
+			resource: Resource{
+				Name:     "Widget",
+				BaseUrl:  "projects/{{project}}/widgets",
+				IdFormat: "projects/{{project}}/widgets/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Output: true},
+				},
+			},
+			identity: true,
+		},
+		{
+			description: "framework resource",
+			resource: Resource{
+				Name:      "Widget",
+				BaseUrl:   "projects/{{project}}/widgets",
+				IdFormat:  "projects/{{project}}/widgets/{{name}}",
+				Framework: true,
+				Properties: []*Type{
+					{Name: "name", Immutable: true},
+				},
+			},
+			identity: true,
+		},
+		{
+			description: "resource isn't read",
+			resource: Resource{
+				Name:        "Widget",
+				BaseUrl:     "projects/{{project}}/widgets",
+				IdFormat:    "projects/{{project}}/widgets/{{name}}",
+				ExcludeRead: true,
+				Properties: []*Type{
+					{Name: "name", Immutable: true},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			for _, p := range tc.resource.Properties {
+				p.ResourceMetadata = &tc.resource
+			}
+			if got, want := tc.resource.HasResourceIdentity(), tc.identity; got != want {
+				t.Errorf("expected HasResourceIdentity to be %t, got %t", want, got)
+			}
+			if got, want := tc.resource.HasMutableIdentity(), tc.mutable; got != want {
+				t.Errorf("expected HasMutableIdentity to be %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestResourceEphemeralFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		uri         string
+		verb        string
+		required    []string
+		optional    []string
+		defaults    map[string]string
+	}{
+		{
+			description: "read through the self link by default",
+			resource: Resource{
+				Name:      "Widget",
+				BaseUrl:   "projects/{{project}}/widgets",
+				Ephemeral: &resource.Ephemeral{Generate: true},
+				Properties: []*Type{
+					{Name: "name"},
+				},
+			},
+			uri:      "projects/{{project}}/widgets/{{name}}",
+			verb:     "GET",
+			required: []string{"name"},
+			optional: []string{"project"},
+		},
+		{
+			description: "fields with a default value are optional",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "projects/{{project}}/locations/{{location}}/widgets",
+				Ephemeral: &resource.Ephemeral{
+					Generate: true,
+					Open: &resource.EphemeralCall{
+						Url:  "projects/{{project}}/locations/{{location}}/widgets:generate",
+						Verb: "POST",
+					},
+				},
+				Parameters: []*Type{
+					{Name: "location", Type: "String", UrlParamOnly: true, DefaultValue: "global"},
+				},
+			},
+			uri:      "projects/{{project}}/locations/{{location}}/widgets:generate",
+			verb:     "POST",
+			optional: []string{"project", "location"},
+			defaults: map[string]string{"location": `"global"`},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.resource.EphemeralOpenUri(), tc.uri; got != want {
+				t.Errorf("expected open uri %q to be %q", got, want)
+			}
+			if got, want := tc.resource.EphemeralOpenVerb(), tc.verb; got != want {
+				t.Errorf("expected open verb %q to be %q", got, want)
+			}
+			if got, want := tc.resource.EphemeralRequiredFields(), tc.required; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected required fields %v to be %v", got, want)
+			}
+			if got, want := tc.resource.EphemeralOptionalFields(), tc.optional; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected optional fields %v to be %v", got, want)
+			}
+			for _, f := range tc.resource.EphemeralConfigFields() {
+				if got, want := tc.resource.EphemeralFieldDefault(f), tc.defaults[f]; got != want {
+					t.Errorf("expected default of %s %q to be %q", f, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceFramework(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		defaults    map[string]bool
+		errorPaths  []string
+	}{
+		{
+			description: "project defaults to the provider",
+			resource: Resource{
+				Name:      "Widget",
+				BaseUrl:   "projects/{{project}}/widgets",
+				Framework: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String", Required: true},
+				},
+			},
+			defaults: map[string]bool{"project": true},
+		},
+		{
+			description: "zone defaults to the provider",
+			resource: Resource{
+				Name:      "Widget",
+				BaseUrl:   "projects/{{project}}/zones/{{zone}}/widgets",
+				Framework: true,
+				Parameters: []*Type{
+					{Name: "zone", Type: "String", IgnoreRead: true, Immutable: true, UrlParamOnly: true},
+				},
+			},
+			defaults: map[string]bool{"project": true, "zone": true},
+		},
+		{
+			description: "default customize diff is excluded",
+			resource: Resource{
+				Name:                "Widget",
+				BaseUrl:             "projects/{{project}}/widgets",
+				Framework:           true,
+				ExcludeDefaultCdiff: true,
+			},
+			defaults: map[string]bool{},
+		},
+		{
+			description: "sdk features aren't supported",
+			resource: Resource{
+				Name:           "Widget",
+				BaseUrl:        "widgets",
+				Framework:      true,
+				StateUpgraders: true,
+				Properties: []*Type{
+					{Name: "name", Type: "String", DiffSuppressFunc: "tpgresource.CaseDiffSuppress"},
+					{Name: "tags", Type: "Array", UnorderedList: true, ItemType: &Type{Type: "String"}},
+				},
+			},
+			defaults:   map[string]bool{},
+			errorPaths: []string{"framework", "framework", "properties.name"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			for _, p := range google.Concat(tc.resource.Properties, tc.resource.Parameters) {
+				p.ResourceMetadata = &tc.resource
+			}
+			if got, want := tc.resource.FrameworkProviderDefaults(), tc.defaults; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected provider defaults %v to be %v", got, want)
+			}
+			var paths []string
+			for _, err := range tc.resource.validateFramework() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceUpdateGroups(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		groups      []*CustomUpdateGroup
+		order       []string
+		errorPaths  []string
+	}{
+		{
+			description: "groups keep their listed order",
+			groups: []*CustomUpdateGroup{
+				{Name: "labels", Fields: []string{"labels"}, UpdateUrl: "widgets/{{name}}:setLabels", UpdateVerb: "POST", FingerprintName: "labelFingerprint"},
+				{Name: "size", Fields: []string{"size", "tier"}, UpdateUrl: "widgets/{{name}}:resize", UpdateVerb: "POST"},
+			},
+			order: []string{"legacy", "labels", "size"},
+		},
+		{
+			description: "groups move after their dependencies",
+			groups: []*CustomUpdateGroup{
+				{Name: "size", Fields: []string{"size"}, UpdateUrl: "widgets/{{name}}:resize", UpdateVerb: "POST", DependsOn: []string{"tier"}},
+				{Name: "labels", Fields: []string{"labels"}, UpdateUrl: "widgets/{{name}}:setLabels", UpdateVerb: "POST"},
+				{Name: "tier", Fields: []string{"tier"}, UpdateUrl: "widgets/{{name}}:setTier", UpdateVerb: "PATCH"},
+			},
+			order: []string{"legacy", "labels", "tier", "size"},
+		},
+		{
+			description: "invalid groups",
+			groups: []*CustomUpdateGroup{
+				{Name: "size", Fields: []string{"size", "color"}, UpdateUrl: "widgets/{{name}}:resize", UpdateVerb: "GET",
+					Precondition: &UpdatePrecondition{Path: "status", Values: []string{"RUNNING"}, SkipValues: []string{"STOPPED"}}},
+				{Name: "tier", Fields: []string{"size", "legacy", "id"}, UpdateUrl: "widgets/{{name}}:setTier", UpdateVerb: "POST", DependsOn: []string{"tier"}},
+			},
+			order:      []string{"legacy", "size"},
+			errorPaths: []string{"update_groups.0.update_verb", "update_groups.0.fields.1", "update_groups.0.precondition", "update_groups.1.fields.0", "update_groups.1.fields.1", "update_groups.1.fields.2", "update_groups.1.depends_on.0"},
+		},
+		{
+			description: "dependency cycle",
+			groups: []*CustomUpdateGroup{
+				{Name: "size", Fields: []string{"size"}, UpdateUrl: "widgets/{{name}}:resize", UpdateVerb: "POST", DependsOn: []string{"tier"}},
+				{Name: "tier", Fields: []string{"tier"}, UpdateUrl: "widgets/{{name}}:setTier", UpdateVerb: "POST", DependsOn: []string{"size"}},
+			},
+			order:      []string{"legacy"},
+			errorPaths: []string{"update_groups"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := Resource{
+				Name:         "Widget",
+				UpdateGroups: tc.groups,
+				Properties: []*Type{
+					{Name: "id", Type: "String", Output: true},
+					{Name: "labels", Type: "KeyValuePairs"},
+					{Name: "size", Type: "Integer"},
+					{Name: "tier", Type: "String"},
+					{Name: "legacy", Type: "String", UpdateUrl: "widgets/{{name}}:setLegacy", UpdateVerb: "POST"},
+				},
+			}
+			for _, p := range r.Properties {
+				p.ResourceMetadata = &r
+			}
+			r.setUpdateGroupDefaults()
+
+			var order []string
+			for _, g := range r.PropertiesByCustomUpdateGroups() {
+				if declared := r.DeclaredUpdateGroup(g); declared != nil {
+					order = append(order, declared.Name)
+				} else {
+					order = append(order, "legacy")
+				}
+			}
+			if got, want := order, tc.order; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected groups to be updated in order %v, got %v", want, got)
+			}
+
+			var paths []string
+			for _, err := range r.validateUpdateGroups() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdatePreconditionSkipCondition(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description  string
+		precondition UpdatePrecondition
+		expected     string
+	}{
+		{
+			description:  "values",
+			precondition: UpdatePrecondition{Path: "status", Values: []string{"RUNNING", "READY"}},
+			expected:     `!slices.Contains([]string{"RUNNING", "READY"}, state)`,
+		},
+		{
+			description:  "skip values",
+			precondition: UpdatePrecondition{Path: "status", SkipValues: []string{"TERMINATED"}},
+			expected:     `slices.Contains([]string{"TERMINATED"}, state)`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.precondition.SkipCondition("state"); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description   string
+		protection    *resource.DeletionProtection
+		virtualFields []*Type
+		field         string
+		virtual       []string
+		diff          string
+		ensureValue   *resource.EnsureValue
+		errorPaths    []string
+	}{
+		{
+			description: "virtual field is generated",
+			protection:  &resource.DeletionProtection{Default: true},
+			field:       "deletion_protection",
+			virtual:     []string{"deletion_protection"},
+		},
+		{
+			description: "api field is swept",
+			protection:  &resource.DeletionProtection{ApiField: "deletionProtection"},
+			field:       "deletion_protection",
+			ensureValue: &resource.EnsureValue{Field: "deletionProtection", Value: "false"},
+		},
+		{
+			description: "replacement is blocked",
+			protection:  &resource.DeletionProtection{Default: true, BlockReplacement: true},
+			field:       "deletion_protection",
+			virtual:     []string{"deletion_protection"},
+			diff:        `tpgresource.DeletionProtectionDiff("deletion_protection", "project", "name", "config.machine_type")`,
+		},
+		{
+			description:   "virtual field is declared",
+			protection:    &resource.DeletionProtection{},
+			virtualFields: []*Type{{Name: "deletion_protection", Type: "Boolean"}},
+			field:         "deletion_protection",
+			virtual:       []string{"deletion_protection", "deletion_protection"},
+			errorPaths:    []string{"virtual_fields"},
+		},
+		{
+			description: "api field with a default",
+			protection:  &resource.DeletionProtection{Default: true, ApiField: "deletionProtection"},
+			field:       "deletion_protection",
+			ensureValue: &resource.EnsureValue{Field: "deletionProtection", Value: "false"},
+			errorPaths:  []string{"deletion_protection.default"},
+		},
+		{
+			description: "api field is missing",
+			protection:  &resource.DeletionProtection{ApiField: "protected"},
+			field:       "protected",
+			errorPaths:  []string{"deletion_protection.api_field"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := Resource{
+				Name:               "Widget",
+				Description:        "A widget.",
+				BaseUrl:            "projects/{{project}}/widgets",
+				SelfLink:           "projects/{{project}}/widgets/{{name}}",
+				DeletionProtection: tc.protection,
+				VirtualFields:      tc.virtualFields,
+				Properties: []*Type{
+					{Name: "name", Type: "String", Required: true, Immutable: true},
+					{Name: "deletionProtection", Type: "Boolean"},
+					{Name: "config", Type: "NestedObject", Properties: []*Type{
+						{Name: "machineType", Type: "String", Immutable: true},
+						{Name: "nodeCount", Type: "Integer"},
+					}},
+				},
+			}
+			r.SetDefault(&Product{Name: "Example"})
+
+			if got, want := r.DeletionProtectionField(), tc.field; got != want {
+				t.Errorf("expected field %q to be %q", got, want)
+			}
+			var virtual []string
+			for _, vf := range r.VirtualFields {
+				virtual = append(virtual, vf.Name)
+			}
+			if got, want := virtual, tc.virtual; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected virtual fields %v to be %v", got, want)
+			}
+			if got, want := r.DeletionProtectionDiff(), tc.diff; got != want {
+				t.Errorf("expected customize diff %q to be %q", got, want)
+			}
+			if got, want := r.Sweeper.EnsureValue, tc.ensureValue; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected sweeper to ensure %v, got %v", want, got)
+			}
+			// Defaulting an existing field would be a breaking change
+			if got := r.Properties[1].DefaultValue; got != nil {
+				t.Errorf("expected default value %v of the API field to be nil", got)
+			}
+
+			var paths []string
+			for _, err := range r.validateDeletionProtection() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
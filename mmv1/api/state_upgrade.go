@@ -0,0 +1,401 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The kinds of changes between two schemas of a resource that its state
+// upgraders handle.
+const (
+	// A field was renamed within the same object.
+	StateChangeRename = "rename"
+	// A field was moved into or out of a nested object.
+	StateChangeMove = "move"
+	// A list was converted to a set, which is stored the same way.
+	StateChangeListToSet = "list_to_set"
+	// A set was converted to a list, which is stored the same way.
+	StateChangeSetToList = "set_to_list"
+	// The type of a field changed, which can't be upgraded mechanically.
+	StateChangeType = "type"
+)
+
+// A change to the state of a resource between two of its schemas. Paths are
+// state paths, where the elements of single nested objects are addressed with
+// 0 and the elements of other lists and sets with *, such as
+// `settings.0.rules.*.action`.
+type StateChange struct {
+	Kind string
+	From string
+	To   string
+}
+
+// Returns true if the generated upgrader handles the change.
+func (c StateChange) Mechanical() bool {
+	return c.Kind != StateChangeType
+}
+
+// Returns true if the generated upgrader moves the value of the field.
+func (c StateChange) MovesValue() bool {
+	return c.Kind == StateChangeRename || c.Kind == StateChangeMove
+}
+
+// Describes the change in the comments of generated upgraders.
+func (c StateChange) Description() string {
+	switch c.Kind {
+	case StateChangeRename:
+		return fmt.Sprintf("%s was renamed to %s", c.From, c.To)
+	case StateChangeMove:
+		return fmt.Sprintf("%s was moved to %s", c.From, c.To)
+	case StateChangeListToSet:
+		return fmt.Sprintf("%s was converted from a list to a set, which is stored the same way", c.From)
+	case StateChangeSetToList:
+		return fmt.Sprintf("%s was converted from a set to a list, which is stored the same way", c.From)
+	}
+	return fmt.Sprintf("the type of %s changed", c.From)
+}
+
+// A generated state upgrader of a resource, which upgrades state from the
+// schema of a snapshot to the next schema version.
+type StateUpgradeStep struct {
+	// The schema version the step upgrades from.
+	Version int
+
+	// The schema the step upgrades from.
+	Schema *resource.SchemaSnapshot
+
+	// The changes to the state from Schema to the next schema version.
+	Changes []StateChange
+}
+
+// Returns the path of the snapshot of a resource's schema at a provider
+// major version, within the directory of its product.
+func SchemaSnapshotPath(productDir, resourceName, version string, providerMajorVersion int) string {
+	return filepath.Join(productDir, "snapshots", version, fmt.Sprintf("%s.v%d.yaml", resourceName, providerMajorVersion))
+}
+
+// Reads the schema snapshots of the resource at a version, which are
+// recorded next to its first YAML file, sorted by the provider major version
+// they were recorded for.
+func (r *Resource) LoadSchemaSnapshots(version string) ValidationErrors {
+	var errs ValidationErrors
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(r.YamlFiles[0]), "snapshots", version, r.Name+".v*.yaml"))
+	if err != nil {
+		errs.Add("", "Cannot get the schema snapshots of %s: %v", r.Name, err)
+		return errs
+	}
+	for _, file := range files {
+		snapshot := &resource.SchemaSnapshot{}
+		if compileErrs := Compile(file, snapshot, ""); len(compileErrs) > 0 {
+			errs.Append(compileErrs)
+			continue
+		}
+		r.SchemaSnapshots = append(r.SchemaSnapshots, snapshot)
+		r.YamlFiles = append(r.YamlFiles, file)
+	}
+	slices.SortFunc(r.SchemaSnapshots, func(a, b *resource.SchemaSnapshot) int {
+		return a.ProviderMajorVersion - b.ProviderMajorVersion
+	})
+	return errs
+}
+
+// Returns the YAML file the resource was last read from: its override if it
+// has one, otherwise its base file. Schema snapshots are loaded after the
+// resource's own files and aren't considered.
+func (r Resource) DefinitionYamlFile() string {
+	definitions := r.YamlFiles[:len(r.YamlFiles)-len(r.SchemaSnapshots)]
+	return definitions[len(definitions)-1]
+}
+
+// Returns the current schema of the resource as a snapshot, without a
+// provider major version.
+func (r Resource) SchemaSnapshot() *resource.SchemaSnapshot {
+	fields := snapshotFields(google.Concat(r.AllUserProperties(), r.UserVirtualFields()))
+	if r.HasProject() {
+		fields = append(fields, &resource.SnapshotField{Name: "project", Type: "String", Optional: true, Computed: true})
+	}
+	if r.HasSelfLink {
+		fields = append(fields, &resource.SnapshotField{Name: "self_link", Type: "String", Computed: true})
+	}
+	sortSnapshotFields(fields)
+
+	return &resource.SchemaSnapshot{
+		SchemaVersion: r.SchemaVersion,
+		Fields:        fields,
+	}
+}
+
+// Returns the state upgraders generated from the schema snapshots of the
+// resource. An upgrader is generated for each snapshot whose schema version
+// is one lower than the next snapshot's, or than the resource's. Resources
+// with state_upgraders write all of their upgraders by hand.
+func (r Resource) StateUpgradeSteps() []StateUpgradeStep {
+	if r.StateUpgraders || len(r.SchemaSnapshots) == 0 {
+		return nil
+	}
+
+	var steps []StateUpgradeStep
+	schemas := append(slices.Clone(r.SchemaSnapshots), r.SchemaSnapshot())
+	for i, prior := range schemas[:len(schemas)-1] {
+		next := schemas[i+1]
+		if next.SchemaVersion != prior.SchemaVersion+1 {
+			continue
+		}
+		steps = append(steps, StateUpgradeStep{
+			Version: prior.SchemaVersion,
+			Schema:  prior,
+			Changes: diffSchemaSnapshots(prior, next),
+		})
+	}
+	return steps
+}
+
+func (r Resource) validateStateUpgrades() ValidationErrors {
+	var errs ValidationErrors
+
+	if r.StateUpgraders || len(r.SchemaSnapshots) == 0 {
+		return errs
+	}
+
+	schemas := append(slices.Clone(r.SchemaSnapshots), r.SchemaSnapshot())
+	for i, prior := range schemas[:len(schemas)-1] {
+		if next := schemas[i+1]; next.SchemaVersion > prior.SchemaVersion+1 {
+			errs.Add("schema_version", "Resource %s's schema_version increased by more than one after its v%d schema snapshot, so its state upgraders must be written by hand with state_upgraders", r.Name, prior.ProviderMajorVersion)
+		}
+	}
+	for _, step := range r.StateUpgradeSteps() {
+		for _, c := range step.Changes {
+			if !c.Mechanical() {
+				errs.Add("schema_version", "The type of %s changed after the v%d schema snapshot of resource %s, which can't be upgraded mechanically, so its state upgraders must be written by hand with state_upgraders", c.From, step.Schema.ProviderMajorVersion, r.Name)
+			}
+		}
+	}
+
+	return errs
+}
+
+func snapshotFields(props []*Type) []*resource.SnapshotField {
+	var fields []*resource.SnapshotField
+	for _, p := range props {
+		if p.FlattenObject {
+			fields = append(fields, snapshotFields(p.RootProperties())...)
+			continue
+		}
+		fields = append(fields, p.snapshotField())
+		if old := p.RenamedFromField(); old != nil {
+			fields = append(fields, old.snapshotField())
+		}
+	}
+	sortSnapshotFields(fields)
+	return fields
+}
+
+func sortSnapshotFields(fields []*resource.SnapshotField) {
+	slices.SortFunc(fields, func(a, b *resource.SnapshotField) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// Returns the field of the SDK schema generated for the property.
+func (t *Type) snapshotField() *resource.SnapshotField {
+	f := &resource.SnapshotField{
+		Name: google.Underscore(t.Name),
+		Type: t.snapshotType(),
+	}
+	switch {
+	case t.Output:
+		f.Computed = true
+	case t.Required && !t.RenameRequired():
+		f.Required = true
+	default:
+		f.Optional = true
+		f.Computed = t.DefaultFromApi
+	}
+
+	switch {
+	case t.IsA("NestedObject"):
+		f.MaxItems = 1
+		f.Fields = snapshotFields(t.NestedProperties())
+	case t.IsA("Map"):
+		f.Fields = append(snapshotFields(t.NestedProperties()), &resource.SnapshotField{Name: t.KeyName, Type: "String", Required: true})
+		sortSnapshotFields(f.Fields)
+	case t.IsA("Array") && t.ItemType.IsA("NestedObject"):
+		f.Fields = snapshotFields(t.NestedProperties())
+	case t.IsA("Array"):
+		f.Elem = t.ItemType.snapshotType()
+	case strings.HasPrefix(t.Type, "KeyValue"):
+		f.Elem = "String"
+	}
+	return f
+}
+
+func (t Type) snapshotType() string {
+	switch {
+	case t.IsA("Boolean"):
+		return "Bool"
+	case t.IsA("Double"):
+		return "Float"
+	case t.IsA("Integer"):
+		return "Int"
+	case t.IsA("NestedObject"):
+		return "List"
+	case t.IsA("Array") && t.IsSet, t.IsA("Map"):
+		return "Set"
+	case t.IsA("Array"):
+		return "List"
+	case strings.HasPrefix(t.Type, "KeyValue"):
+		return "Map"
+	}
+	return "String"
+}
+
+// A field of a schema snapshot, with its state path and the keys of the
+// field and its parent, which are the names of the fields leading to them.
+type snapshotEntry struct {
+	field     *resource.SnapshotField
+	path      string
+	parentKey string
+}
+
+func snapshotEntries(fields []*resource.SnapshotField, parentKey, parentPath string, entries map[string]snapshotEntry) map[string]snapshotEntry {
+	for _, f := range fields {
+		key := joinPath(parentKey, f.Name)
+		path := joinPath(parentPath, f.Name)
+		entries[key] = snapshotEntry{field: f, path: path, parentKey: parentKey}
+		if f.IsObject() {
+			index := "*"
+			if f.IsSingleObject() {
+				index = "0"
+			}
+			snapshotEntries(f.Fields, key, joinPath(path, index), entries)
+		}
+	}
+	return entries
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// Returns the part of a state path up to its last element of a list or set
+// of many objects, which a field can't be moved out of mechanically.
+func repeatedPrefix(path string) string {
+	if i := strings.LastIndex(path, ".*."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// Compares two schemas of a resource. Fields that were removed are matched
+// with fields of the same shape that were added, as renames if they have the
+// same parent and as moves if they have the same name. Fields are only
+// matched if no other field could match them.
+func diffSchemaSnapshots(prior, next *resource.SchemaSnapshot) []StateChange {
+	before := snapshotEntries(prior.Fields, "", "", make(map[string]snapshotEntry))
+	after := snapshotEntries(next.Fields, "", "", make(map[string]snapshotEntry))
+
+	var changes []StateChange
+	changed := make(map[string]bool)
+	var removed, added []string
+
+	for _, key := range sortedKeys(before) {
+		b := before[key]
+		if changed[b.parentKey] {
+			changed[key] = true
+			continue
+		}
+		a, ok := after[key]
+		if !ok {
+			removed = append(removed, key)
+			continue
+		}
+		switch {
+		case b.field.Type == "List" && a.field.Type == "Set" && b.field.SameShape(*a.field):
+			changes = append(changes, StateChange{Kind: StateChangeListToSet, From: b.path, To: a.path})
+		case b.field.Type == "Set" && a.field.Type == "List" && b.field.SameShape(*a.field):
+			changes = append(changes, StateChange{Kind: StateChangeSetToList, From: b.path, To: a.path})
+		case b.field.Type != a.field.Type || b.field.Elem != a.field.Elem || b.field.IsObject() != a.field.IsObject():
+			changed[key] = true
+			changes = append(changes, StateChange{Kind: StateChangeType, From: b.path, To: a.path})
+		}
+	}
+	for _, key := range sortedKeys(after) {
+		if _, ok := before[key]; !ok && !changed[after[key].parentKey] {
+			added = append(added, key)
+		}
+	}
+
+	var moves []StateChange
+	matchFields := func(kind string, matches func(b, a snapshotEntry) bool) {
+		candidates := make(map[string][]string)
+		counts := make(map[string]int)
+		for _, r := range removed {
+			for _, n := range added {
+				if matches(before[r], after[n]) && before[r].field.SameShape(*after[n].field) {
+					candidates[r] = append(candidates[r], n)
+					counts[n]++
+				}
+			}
+		}
+		var matched []string
+		for _, r := range removed {
+			if len(candidates[r]) != 1 || counts[candidates[r][0]] != 1 {
+				continue
+			}
+			n := candidates[r][0]
+			moves = append(moves, StateChange{Kind: kind, From: before[r].path, To: after[n].path})
+			matched = append(matched, r, n)
+		}
+		removed = google.Reject(removed, func(k string) bool { return slices.Contains(matched, k) })
+		added = google.Reject(added, func(k string) bool { return slices.Contains(matched, k) })
+	}
+	matchFields(StateChangeMove, func(b, a snapshotEntry) bool {
+		return b.parentKey != a.parentKey && b.field.Name == a.field.Name && repeatedPrefix(b.path) == repeatedPrefix(a.path)
+	})
+	matchFields(StateChangeRename, func(b, a snapshotEntry) bool {
+		return b.parentKey == a.parentKey
+	})
+
+	// Fields of an object that was renamed or moved are moved along with it.
+	for _, m := range moves {
+		if !slices.ContainsFunc(moves, func(outer StateChange) bool {
+			return strings.HasPrefix(m.From, outer.From+".") && strings.HasPrefix(m.To, outer.To+".")
+		}) {
+			changes = append(changes, m)
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys(entries map[string]snapshotEntry) []string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestDiffSchemaSnapshots(t *testing.T) {
+	t.Parallel()
+
+	str := func(name string) *resource.SnapshotField {
+		return &resource.SnapshotField{Name: name, Type: "String", Optional: true}
+	}
+	object := func(name string, maxItems int, fields ...*resource.SnapshotField) *resource.SnapshotField {
+		return &resource.SnapshotField{Name: name, Type: "List", Optional: true, MaxItems: maxItems, Fields: fields}
+	}
+
+	cases := []struct {
+		description string
+		prior       []*resource.SnapshotField
+		next        []*resource.SnapshotField
+		changes     []StateChange
+	}{
+		{
+			description: "no changes",
+			prior:       []*resource.SnapshotField{str("name")},
+			next:        []*resource.SnapshotField{str("name")},
+		},
+		{
+			description: "rename",
+			prior:       []*resource.SnapshotField{str("name"), str("topic_name")},
+			next:        []*resource.SnapshotField{str("name"), str("topic")},
+			changes: []StateChange{
+				{Kind: StateChangeRename, From: "topic_name", To: "topic"},
+			},
+		},
+		{
+			description: "ambiguous renames aren't matched",
+			prior:       []*resource.SnapshotField{str("a"), str("b")},
+			next:        []*resource.SnapshotField{str("c"), str("d")},
+		},
+		{
+			description: "move into a new nested object",
+			prior:       []*resource.SnapshotField{str("name"), str("tier")},
+			next:        []*resource.SnapshotField{str("name"), object("settings", 1, str("tier"))},
+			changes: []StateChange{
+				{Kind: StateChangeMove, From: "tier", To: "settings.0.tier"},
+			},
+		},
+		{
+			description: "move out of a nested object",
+			prior:       []*resource.SnapshotField{object("settings", 1, str("size"), str("tier"))},
+			next:        []*resource.SnapshotField{object("settings", 1, str("size")), str("tier")},
+			changes: []StateChange{
+				{Kind: StateChangeMove, From: "settings.0.tier", To: "tier"},
+			},
+		},
+		{
+			description: "fields of a renamed object move with it",
+			prior:       []*resource.SnapshotField{object("config", 1, str("size"), str("tier"))},
+			next:        []*resource.SnapshotField{object("settings", 1, str("size"), str("tier"))},
+			changes: []StateChange{
+				{Kind: StateChangeRename, From: "config", To: "settings"},
+			},
+		},
+		{
+			description: "rename in a list of objects",
+			prior:       []*resource.SnapshotField{object("rules", 0, str("old"))},
+			next:        []*resource.SnapshotField{object("rules", 0, str("new"))},
+			changes: []StateChange{
+				{Kind: StateChangeRename, From: "rules.*.old", To: "rules.*.new"},
+			},
+		},
+		{
+			description: "fields aren't moved out of a list of objects",
+			prior:       []*resource.SnapshotField{object("rules", 0, str("action"), str("priority"))},
+			next:        []*resource.SnapshotField{object("rules", 0, str("priority")), str("action")},
+		},
+		{
+			description: "list converted to a set",
+			prior:       []*resource.SnapshotField{{Name: "tags", Type: "List", Elem: "String"}},
+			next:        []*resource.SnapshotField{{Name: "tags", Type: "Set", Elem: "String"}},
+			changes: []StateChange{
+				{Kind: StateChangeListToSet, From: "tags", To: "tags"},
+			},
+		},
+		{
+			description: "type changed",
+			prior:       []*resource.SnapshotField{str("size")},
+			next:        []*resource.SnapshotField{{Name: "size", Type: "Int", Optional: true}},
+			changes: []StateChange{
+				{Kind: StateChangeType, From: "size", To: "size"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			changes := diffSchemaSnapshots(&resource.SchemaSnapshot{Fields: tc.prior}, &resource.SchemaSnapshot{Fields: tc.next})
+			if got, want := changes, tc.changes; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected changes %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceStateUpgradeSteps(t *testing.T) {
+	t.Parallel()
+
+	snapshot := func(major, schemaVersion int, fields ...string) *resource.SchemaSnapshot {
+		s := &resource.SchemaSnapshot{ProviderMajorVersion: major, SchemaVersion: schemaVersion}
+		for _, f := range fields {
+			s.Fields = append(s.Fields, &resource.SnapshotField{Name: f, Type: "String", Optional: true})
+		}
+		return s
+	}
+
+	cases := []struct {
+		description   string
+		resource      Resource
+		versions      []int
+		invalid       bool
+		changedFields []string
+	}{
+		{
+			description: "schema version unchanged",
+			resource: Resource{
+				Name:            "Widget",
+				SchemaSnapshots: []*resource.SchemaSnapshot{snapshot(6, 0, "name")},
+				Properties:      []*Type{{Name: "name", Type: "String"}},
+			},
+		},
+		{
+			description: "schema version increased",
+			resource: Resource{
+				Name:            "Widget",
+				SchemaVersion:   1,
+				SchemaSnapshots: []*resource.SchemaSnapshot{snapshot(6, 0, "widget_name")},
+				Properties:      []*Type{{Name: "name", Type: "String"}},
+			},
+			versions:      []int{0},
+			changedFields: []string{"widget_name"},
+		},
+		{
+			description: "upgrades between snapshots",
+			resource: Resource{
+				Name:          "Widget",
+				SchemaVersion: 2,
+				SchemaSnapshots: []*resource.SchemaSnapshot{
+					snapshot(6, 0, "widget_name"),
+					snapshot(7, 1, "name"),
+				},
+				Properties: []*Type{{Name: "name", Type: "String"}},
+			},
+			versions:      []int{0, 1},
+			changedFields: []string{"widget_name"},
+		},
+		{
+			description: "schema version increased by more than one",
+			resource: Resource{
+				Name:            "Widget",
+				SchemaVersion:   2,
+				SchemaSnapshots: []*resource.SchemaSnapshot{snapshot(6, 0, "name")},
+				Properties:      []*Type{{Name: "name", Type: "String"}},
+			},
+			invalid: true,
+		},
+		{
+			description: "handwritten state upgraders",
+			resource: Resource{
+				Name:            "Widget",
+				SchemaVersion:   1,
+				StateUpgraders:  true,
+				SchemaSnapshots: []*resource.SchemaSnapshot{snapshot(6, 0, "widget_name")},
+				Properties:      []*Type{{Name: "name", Type: "String"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			for _, p := range tc.resource.Properties {
+				p.ResourceMetadata = &tc.resource
+			}
+			var versions []int
+			var changedFields []string
+			for _, step := range tc.resource.StateUpgradeSteps() {
+				versions = append(versions, step.Version)
+				for _, c := range step.Changes {
+					changedFields = append(changedFields, c.From)
+				}
+			}
+			if got, want := versions, tc.versions; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected upgraded versions %v to be %v", got, want)
+			}
+			if got, want := changedFields, tc.changedFields; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected changed fields %v to be %v", got, want)
+			}
+			if got, want := len(tc.resource.validateStateUpgrades()) > 0, tc.invalid; got != want {
+				t.Errorf("expected invalid to be %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestResourceDefinitionYamlFile(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &resource.SchemaSnapshot{ProviderMajorVersion: 6}
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    string
+	}{
+		{
+			description: "base file",
+			obj: Resource{
+				YamlFiles: []string{"products/foo/Bar.yaml"},
+			},
+			expected: "products/foo/Bar.yaml",
+		},
+		{
+			description: "override",
+			obj: Resource{
+				YamlFiles: []string{"products/foo/Bar.yaml", "overrides/products/foo/Bar.yaml"},
+			},
+			expected: "overrides/products/foo/Bar.yaml",
+		},
+		{
+			description: "base file with snapshots",
+			obj: Resource{
+				YamlFiles:       []string{"products/foo/Bar.yaml", "products/foo/snapshots/ga/Bar.v6.yaml"},
+				SchemaSnapshots: []*resource.SchemaSnapshot{snapshot},
+			},
+			expected: "products/foo/Bar.yaml",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.DefinitionYamlFile(); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package api
 import (
 	"fmt"
 	"log"
+	"path/filepath"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -57,6 +58,14 @@ type Type struct {
 	// a different version.
 	RemovedMessage string `yaml:"removed_message,omitempty"`
 
+	// The name this field had before it was renamed, in camelCase. The old
+	// name is kept as a deprecated field that conflicts with this one, and
+	// whichever of the two is configured is sent to the API and read back,
+	// until the old name is removed in a major release.
+	// Only supported for top-level fields that aren't used in the resource's
+	// URLs or id.
+	RenamedFrom string `yaml:"renamed_from,omitempty"`
+
 	// If set value will not be sent to server on sync.
 	// For nested fields, this also needs to be set on each descendant (ie. self,
 	// child, etc.).
@@ -160,6 +169,24 @@ type Type struct {
 	Resource string `yaml:"resource,omitempty"`
 	Imports  string `yaml:"imports,omitempty"`
 
+	// The type of the referenced resource, as the name of a resource of the
+	// same product or as "product/Resource" for a resource of another product,
+	// such as "compute/Network". The expander, diff suppression and
+	// documentation of the reference are derived from the target's self_link.
+	Target string `yaml:"target,omitempty"`
+
+	// The format a reference with a target is sent to the API in: "name",
+	// "relative_name" or "self_link". Defaults to "self_link" if the field
+	// imports the target's selfLink, and to "relative_name" otherwise.
+	ReferenceFormat string `yaml:"reference_format,omitempty"`
+
+	// If true, a reference with a target is sent as it's written, for APIs
+	// whose references don't follow the target's self_link.
+	ExcludeReferenceNormalization bool `yaml:"exclude_reference_normalization,omitempty"`
+
+	// The resource read from the target of the reference.
+	TargetMetadata *Resource `yaml:"-"`
+
 	// ====================
 	// Terraform Overrides
 	// ====================
@@ -290,6 +317,10 @@ type Type struct {
 
 	ParentMetadata *Type `yaml:"parent_metadata,omitempty"` // is nil for top-level properties
 
+	// The renamed field a deprecated field was generated for, to keep its old
+	// name working. See RenamedFromField.
+	RenamedTo *Type `yaml:"-"`
+
 	// The prefix used as part of the property expand/flatten function name
 	// flatten{{$.GetPrefix}}{{$.TitlelizeProperty}}
 	Prefix string `yaml:"prefix,omitempty"`
@@ -325,6 +356,10 @@ func (t *Type) SetDefault(r *Resource) {
 
 	switch {
 	case t.IsA("Array"):
+		// A missing item_type is reported by Validate
+		if t.ItemType == nil {
+			break
+		}
 		t.ItemType.Name = t.Name
 		t.ItemType.ParentName = t.Name
 		t.ItemType.ParentMetadata = t
@@ -333,6 +368,10 @@ func (t *Type) SetDefault(r *Resource) {
 		if t.KeyExpander == "" {
 			t.KeyExpander = "tpgresource.ExpandString"
 		}
+		// A missing value_type is reported by Validate
+		if t.ValueType == nil {
+			break
+		}
 		t.ValueType.ParentName = t.Name
 		t.ValueType.ParentMetadata = t
 		t.ValueType.SetDefault(r)
@@ -350,9 +389,31 @@ func (t *Type) SetDefault(r *Resource) {
 			p.SetDefault(r)
 		}
 	case t.IsA("ResourceRef"):
+		if t.Resource == "" && t.Target != "" {
+			t.Resource = t.Target[strings.LastIndex(t.Target, "/")+1:]
+		}
 		if t.Name == "" {
 			t.Name = t.Resource
 		}
+		if t.Target != "" {
+			if t.ReferenceFormat == "" {
+				t.ReferenceFormat = "relative_name"
+				if t.Imports == "selfLink" {
+					t.ReferenceFormat = "self_link"
+				}
+			}
+			// A target that can't be read is reported by Validate
+			t.TargetMetadata, _ = loadTarget(r.ProductMetadata, t.Target)
+			if t.TargetMetadata != nil {
+				// The generated code depends on the target's definition
+				files := append(slices.Clone(t.TargetMetadata.ProductMetadata.YamlFiles), t.TargetMetadata.YamlFiles...)
+				for _, file := range files {
+					if !slices.Contains(r.YamlFiles, file) && !slices.Contains(r.ProductMetadata.YamlFiles, file) {
+						r.YamlFiles = append(r.YamlFiles, file)
+					}
+				}
+			}
+		}
 
 		if t.Description == "" {
 			t.Description = fmt.Sprintf("A reference to %s resource", t.Resource)
@@ -370,40 +431,58 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
-func (t *Type) Validate(rName string) {
+func (t *Type) Validate(rName string) ValidationErrors {
+	var errs ValidationErrors
+	path := t.YamlPath()
+
 	if t.Name == "" {
-		log.Fatalf("Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+		errs.Add(path, "Missing `name` for proprty with type %s in resource %s", t.Type, rName)
 	}
 
 	if t.Output && t.Required {
-		log.Fatalf("Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
+		errs.Add(path, "Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		log.Fatalf("'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
+		errs.Add(path, "'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
 	}
 
 	if t.WriteOnly && (t.DefaultFromApi || t.Output) {
-		log.Fatalf("Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
+		errs.Add(path, "Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
 	}
 
 	if t.WriteOnly && t.Sensitive {
-		log.Fatalf("Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
+		errs.Add(path, "Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
 	}
 
-	t.validateLabelsField()
+	errs.Append(t.validateLabelsField())
+	errs.Append(t.validateRenamedFrom())
+	errs.Append(t.validateReference())
 
 	switch {
 	case t.IsA("Array"):
-		t.ItemType.Validate(rName)
+		if t.ItemType == nil {
+			errs.Add(path, "Missing `item_type` for Array property %s in resource %s", t.Name, rName)
+			break
+		}
+		errs.Append(t.ItemType.Validate(rName))
 	case t.IsA("Map"):
-		t.ValueType.Validate(rName)
+		if t.ValueType == nil {
+			errs.Add(path, "Missing `value_type` for Map property %s in resource %s", t.Name, rName)
+			break
+		}
+		errs.Append(t.ValueType.Validate(rName))
 	case t.IsA("NestedObject"):
+		if t.Properties == nil {
+			errs.Add(path, "Missing `properties` for NestedObject property %s in resource %s", t.Name, rName)
+		}
 		for _, p := range t.Properties {
-			p.Validate(rName)
+			errs.Append(p.Validate(rName))
 		}
 	default:
 	}
+
+	return errs
 }
 
 // TODO rewrite: add validations
@@ -417,6 +496,33 @@ func (t *Type) Validate(rName string) {
 // check the allowed types for Type field
 // check the allowed fields for each type, for example, KeyName is only allowed for Map
 
+// Returns a dot notation path to where the field is declared in the resource
+// YAML file. eg: properties.networkConfig.subnet
+// Array item types and Map value types share the path of their parent, as
+// their properties are addressed as if declared on the parent directly.
+func (t *Type) YamlPath() string {
+	parent := t.ParentMetadata
+	if parent == nil {
+		section := "properties"
+		if r := t.ResourceMetadata; r != nil {
+			if slices.Contains(r.Parameters, t) {
+				section = "parameters"
+			} else if slices.Contains(r.VirtualFields, t) {
+				section = "virtual_fields"
+			} else if i := slices.IndexFunc(r.Actions, func(a *Action) bool { return slices.Contains(a.Properties, t) }); i >= 0 {
+				section = fmt.Sprintf("actions.%d.properties", i)
+			}
+		}
+		return joinYamlPath(section, t.Name)
+	}
+
+	if t == parent.ItemType || t == parent.ValueType {
+		return parent.YamlPath()
+	}
+
+	return joinYamlPath(parent.YamlPath(), t.Name)
+}
+
 // Prints a dot notation path to where the field is nested within the parent
 // object. eg: parent.meta.label.foo
 // The only intended purpose is to allow better error messages. Some objects
@@ -579,6 +685,85 @@ func (t Type) Conflicting() []string {
 	return t.Conflicts
 }
 
+// Returns the schema paths of the fields that conflict with this field,
+// including the other name of an optional renamed field.
+func (t Type) ConflictsWithPaths() []string {
+	paths := t.GetPropertySchemaPathList(t.Conflicting())
+	if alias := t.RenameAlias(); alias != "" && !t.RenameRequired() {
+		paths = append(paths, alias)
+	}
+	return paths
+}
+
+// Returns the schema paths of the fields that exactly one of must be set,
+// including both names of a required renamed field.
+func (t Type) ExactlyOneOfPaths() []string {
+	paths := t.GetPropertySchemaPathList(t.ExactlyOneOfList())
+	if t.RenameRequired() {
+		if t.RenamedTo != nil {
+			paths = append(paths, t.RenameAlias(), google.Underscore(t.Name))
+		} else {
+			paths = append(paths, google.Underscore(t.Name), t.RenameAlias())
+		}
+	}
+	return paths
+}
+
+// Returns the deprecated field generated for the old name of a renamed
+// field, or nil if the field wasn't renamed. It shares the schema of the
+// field, but is never required and doesn't share its relations to other
+// fields.
+func (t *Type) RenamedFromField() *Type {
+	if t.RenamedFrom == "" {
+		return nil
+	}
+
+	old := *t
+	old.Name = t.RenamedFrom
+	old.RenamedFrom = ""
+	old.Required = false
+	old.DeprecationMessage = fmt.Sprintf("`%s` is deprecated and will be removed in a future major release. Use `%s` instead.", google.Underscore(t.RenamedFrom), google.Underscore(t.Name))
+	old.Conflicts = nil
+	old.AtLeastOneOf = nil
+	old.ExactlyOneOf = nil
+	old.RequiredWith = nil
+	old.RenamedTo = t
+	return &old
+}
+
+// Returns the Terraform name of the other field of a renamed field: the
+// deprecated old name of a renamed field, or the new name of a deprecated
+// old name.
+func (t Type) RenameAlias() string {
+	if t.RenamedFrom != "" {
+		return google.Underscore(t.RenamedFrom)
+	}
+	if t.RenamedTo != nil {
+		return google.Underscore(t.RenamedTo.Name)
+	}
+	return ""
+}
+
+// Returns true if the field is a required renamed field, or its deprecated
+// old name, in which case exactly one of the two names must be set.
+func (t Type) RenameRequired() bool {
+	if t.RenamedTo != nil {
+		return t.RenamedTo.Required
+	}
+	return t.RenamedFrom != "" && t.Required
+}
+
+// Returns the Go expression of the key a top-level field is read from and
+// written to in ResourceData. A renamed field uses its deprecated old name
+// if that's the name it's configured with.
+func (t Type) ResourceDataKey() string {
+	name := google.Underscore(t.Name)
+	if t.RenamedFrom == "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return fmt.Sprintf("tpgresource.RenamedFieldName(d, %q, %q)", name, t.RenameAlias())
+}
+
 // TODO rewrite: validation
 // Checks that all properties that needs at least one of their fields actually exist.
 // This currently just returns if empty, because we don't want to do the check, since
@@ -805,6 +990,135 @@ func (t Type) TFType(s string) string {
 	return "schema.TypeString"
 }
 
+// Returns the kind of the framework attribute of the field, which is the
+// prefix of the attribute and validator types for it, such as String for
+// schema.StringAttribute and validator.String.
+func (t Type) FrameworkType() string {
+	switch {
+	case t.IsA("Boolean"):
+		return "Bool"
+	case t.IsA("Double"):
+		return "Float64"
+	case t.IsA("Integer"):
+		return "Int64"
+	case t.IsA("NestedObject"):
+		return "List"
+	case t.IsA("Array") && t.IsSet:
+		return "Set"
+	case t.IsA("Array"):
+		return "List"
+	case t.IsA("Map"):
+		return "Set"
+	case strings.HasPrefix(t.Type, "KeyValue"):
+		return "Map"
+	}
+	return "String"
+}
+
+// Returns the Go expression of the element type of a framework collection
+// attribute of primitive values.
+func (t Type) FrameworkElementType() string {
+	if t.IsA("Array") {
+		switch t.ItemType.Type {
+		case "Boolean":
+			return "types.BoolType"
+		case "Double":
+			return "types.Float64Type"
+		case "Integer":
+			return "types.Int64Type"
+		}
+	}
+	return "types.StringType"
+}
+
+// Returns true if the field holds nested objects, which are framework blocks
+// if they can be configured and nested attributes otherwise, matching how
+// the SDK represents them.
+func (t Type) IsFrameworkNested() bool {
+	return t.IsA("NestedObject") || t.IsA("Map") || (t.IsA("Array") && t.ItemType.IsA("NestedObject"))
+}
+
+// Returns true if the field is a framework block
+func (t Type) IsFrameworkBlock() bool {
+	return t.IsFrameworkNested() && !t.Output
+}
+
+// Returns true if the field must not be empty, as it is part of the id of
+// its resource.
+func (t Type) IsFrameworkNonEmpty() bool {
+	if t.ParentMetadata != nil || !t.Required || t.FrameworkType() != "String" {
+		return false
+	}
+	return slices.Contains(t.ResourceMetadata.ExtractIdentifiers(t.ResourceMetadata.GetIdFormat()), google.Underscore(t.Name))
+}
+
+// Returns true if changing the field replaces its framework resource. Fields
+// defaulting to the provider configuration are replaced when planning instead.
+func (t Type) FrameworkRequiresReplace() bool {
+	if t.ParentMetadata == nil && t.ResourceMetadata.IsFrameworkProviderDefault(google.Underscore(t.Name)) {
+		return false
+	}
+	return t.IsForceNew()
+}
+
+// Returns true if the framework resource keeps the value of the field in
+// state when it isn't configured, as the SDK does for fields read from the
+// API.
+func (t Type) FrameworkUseStateForUnknown() bool {
+	if t.ParentMetadata == nil && t.ResourceMetadata.IsFrameworkProviderDefault(google.Underscore(t.Name)) {
+		return false
+	}
+	return t.DefaultFromApi && !t.IsFrameworkNested()
+}
+
+// Returns true if the field has a default_value the framework attribute can
+// default to.
+func (t Type) HasFrameworkDefault() bool {
+	return t.DefaultValue != nil && slices.Contains([]string{"String", "Int64", "Float64", "Bool"}, t.FrameworkType())
+}
+
+// Returns true if the framework attribute or block of the field has
+// validators.
+func (t Type) HasFrameworkValidators() bool {
+	return t.IsA("NestedObject") || t.IsA("Enum") || t.Validation.Regex != "" || t.IsFrameworkNonEmpty() ||
+		(t.IsFrameworkBlock() && t.Required) || t.MinSize != "" || t.MaxSize != "" ||
+		(t.IsA("Array") && (t.ItemType.IsA("Enum") || t.ItemValidation.Regex != "")) ||
+		len(t.Conflicting()) > 0 || len(t.ExactlyOneOfList()) > 0 || len(t.AtLeastOneOfList()) > 0 || len(t.RequiredWithList()) > 0
+}
+
+// Features of the SDK schema that generated framework resources don't
+// support yet, as they are implemented with SDK functions.
+func (t *Type) validateFramework() ValidationErrors {
+	var errs ValidationErrors
+	path := t.YamlPath()
+	rName := t.ResourceMetadata.Name
+
+	switch {
+	case t.DiffSuppressFunc != "" || t.KeyDiffSuppressFunc != "":
+		errs.Add(path, "Property %s of framework resource %s can't use a diff_suppress_func", t.Name, rName)
+	case t.StateFunc != "":
+		errs.Add(path, "Property %s of framework resource %s can't use a state_func", t.Name, rName)
+	case t.Validation.Function != "" || t.ItemValidation.Function != "":
+		errs.Add(path, "Property %s of framework resource %s can't be validated by a function; use a regex instead", t.Name, rName)
+	case t.WriteOnly:
+		errs.Add(path, "Property %s of framework resource %s can't be write_only", t.Name, rName)
+	case !t.Output && (t.IsA("ResourceRef") || (t.IsA("Array") && t.ItemType.IsA("ResourceRef"))):
+		errs.Add(path, "Property %s of framework resource %s can't be a ResourceRef, as references are compared by the SDK", t.Name, rName)
+	case t.RenamedFrom != "":
+		errs.Add(path, "Property %s of framework resource %s can't be renamed_from", t.Name, rName)
+	case t.IsFrameworkBlock() && t.DefaultFromApi:
+		errs.Add(path, "Property %s of framework resource %s can't be default_from_api, as framework blocks can't be computed", t.Name, rName)
+	}
+
+	if !t.Output {
+		for _, p := range t.NestedProperties() {
+			errs.Append(p.validateFramework())
+		}
+	}
+
+	return errs
+}
+
 // TODO rewrite: validation
 // // Represents an enum, and store is valid values
 // class Enum < Primitive
@@ -870,6 +1184,154 @@ func (t Type) IsResourceRefFound() bool {
 	return len(resources) != 0
 }
 
+// Reads the target of a reference, which is either a resource of the product
+// or, as "product/Resource", a resource of another product under the same
+// directory. Overrides of the target are applied, and the files that were
+// read are recorded in the YamlFiles of the target and of its product.
+func loadTarget(p *Product, target string) (*Resource, error) {
+	if p == nil || len(p.YamlFiles) == 0 {
+		return nil, fmt.Errorf("the product of the reference isn't known")
+	}
+
+	// Resources are read relative to the base product directory, even if the
+	// product only exists in the overrides
+	productDir := filepath.Dir(p.YamlFiles[0])
+	if p.OverrideDirectory != "" {
+		if rel, err := filepath.Rel(p.OverrideDirectory, productDir); err == nil && !strings.HasPrefix(rel, "..") {
+			productDir = rel
+		}
+	}
+
+	targetProduct := p
+	name := target
+	if productName, resourceName, ok := strings.Cut(target, "/"); ok {
+		productDir = filepath.Join(filepath.Dir(productDir), productName)
+		name = resourceName
+
+		targetProduct = &Product{OverrideDirectory: p.OverrideDirectory}
+		files, errs := compileWithOverrides(filepath.Join(productDir, "product.yaml"), targetProduct, p.OverrideDirectory)
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		targetProduct.YamlFiles = files
+	}
+
+	r := &Resource{}
+	files, errs := compileWithOverrides(filepath.Join(productDir, name+".yaml"), r, p.OverrideDirectory)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	r.YamlFiles = files
+	targetProduct.ApplyResourceDefaults(r)
+	r.ProductMetadata = targetProduct
+	return r, nil
+}
+
+// Returns true if the reference is normalized to the format of its target.
+func (t Type) NormalizesReference() bool {
+	return t.IsA("ResourceRef") && t.TargetMetadata != nil && !t.ExcludeReferenceNormalization
+}
+
+// Returns the relative name of the reference's target, with placeholders
+// for its fields.
+func (t Type) TargetRelativeName() string {
+	return t.TargetMetadata.SelfLinkUri()
+}
+
+// Returns the expression normalizing the reference in varName to the format
+// the API expects.
+func (t Type) ReferenceNormalizer(varName string) string {
+	basePath := `""`
+	if t.ReferenceFormat == "self_link" {
+		basePath = fmt.Sprintf("config.%sBasePath", t.TargetMetadata.ProductMetadata.Name)
+	}
+	return fmt.Sprintf("tpgresource.NormalizeReference(%s, %q, %q, %s, d, config)", varName, t.TargetRelativeName(), t.ReferenceFormat, basePath)
+}
+
+// Returns the function suppressing diffs between the ways a reference can be
+// written.
+func (t Type) ReferenceDiffSuppressFunc() string {
+	if t.NormalizesReference() && t.ReferenceFormat == "name" {
+		return "tpgresource.CompareResourceNames"
+	}
+	return "tpgresource.CompareSelfLinkOrResourceName"
+}
+
+// Returns the documentation of the values a reference, or an array of them,
+// accepts.
+func (t Type) ReferenceDocs() string {
+	ref := &t
+	if t.IsA("Array") && t.ItemType != nil {
+		ref = t.ItemType
+	}
+	if !ref.NormalizesReference() {
+		return ""
+	}
+
+	relativeName := ref.TargetRelativeName()
+	docs := fmt.Sprintf("Accepts the name, the relative resource name (`%s`) or the self link of a `%s`", relativeName, ref.TargetMetadata.TerraformName())
+	switch ref.ReferenceFormat {
+	case "name":
+		return docs + ", of which only the name is sent."
+	case "self_link":
+		docs += ", which is sent as a self link."
+	default:
+		docs += "."
+	}
+
+	var fields []string
+	for _, f := range ref.referenceFields() {
+		fields = append(fields, fmt.Sprintf("`%s`", f))
+	}
+	if len(fields) > 0 {
+		docs += fmt.Sprintf(" A name is completed with the %s of this resource.", strings.Join(fields, " and "))
+	}
+	return docs
+}
+
+// Returns the fields a name is completed with to form the relative name of
+// the reference's target.
+func (t Type) referenceFields() []string {
+	fields := t.ResourceMetadata.ExtractIdentifiers(t.TargetRelativeName())
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields[:len(fields)-1]
+}
+
+func (t Type) validateReference() ValidationErrors {
+	var errs ValidationErrors
+	path := t.YamlPath()
+
+	if t.Target == "" {
+		return errs
+	}
+	if !t.IsA("ResourceRef") {
+		errs.Add(path, "Property %s can't have a `target`, as it isn't a ResourceRef", t.Name)
+		return errs
+	}
+
+	if _, err := loadTarget(t.ResourceMetadata.ProductMetadata, t.Target); err != nil {
+		errs.Add(path, "Cannot read the target %s of property %s: %s", t.Target, t.Name, err)
+		return errs
+	}
+
+	allowed := []string{"name", "relative_name", "self_link"}
+	if !slices.Contains(allowed, t.ReferenceFormat) {
+		errs.Add(path, "Value on `reference_format` should be one of %#v", allowed)
+	}
+	if t.ReferenceFormat == "name" || !t.NormalizesReference() || t.CustomExpand != "" {
+		return errs
+	}
+	for _, f := range t.referenceFields() {
+		if !slices.Contains([]string{"project", "region", "zone"}, f) && !t.ResourceMetadata.hasSchemaField(f) {
+			errs.Add(path, "Names of %s can't be completed to a relative name, as resource %s has no field %s; set `reference_format: name` or `exclude_reference_normalization`", t.Target, t.ResourceMetadata.Name, f)
+		}
+	}
+
+	return errs
+}
+
 // TODO rewrite: validation
 //   func (t *Type) check_resource_ref_property_exists
 //     return unless defined?(resource_ref.all_user_properties)
@@ -1005,7 +1467,44 @@ func propertyWithIgnoreWrite(ignoreWrite bool) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+// Checks that the old name of a renamed field can be kept working alongside
+// its new name.
+func (t *Type) validateRenamedFrom() ValidationErrors {
+	var errs ValidationErrors
+	if t.RenamedFrom == "" {
+		return errs
+	}
+	path := t.YamlPath()
+	r := t.ResourceMetadata
+
+	switch {
+	case t.ParentMetadata != nil:
+		errs.Add(path, "Property %s in resource %s can't be renamed_from, as only top-level fields can be renamed", t.Name, r.Name)
+	case t.Output || t.UrlParamOnly || t.FlattenObject:
+		errs.Add(path, "Property %s in resource %s can't be renamed_from, as it's output, url_param_only or flatten_object", t.Name, r.Name)
+	case t.DefaultValue != nil:
+		errs.Add(path, "Property %s in resource %s can't be renamed_from, as it has a default_value", t.Name, r.Name)
+	}
+
+	for _, p := range google.Concat(r.AllUserProperties(), r.VirtualFields) {
+		if p.Name == t.RenamedFrom {
+			errs.Add(path, "Property %s in resource %s is renamed_from %s, which is the name of another field", t.Name, r.Name, t.RenamedFrom)
+		}
+	}
+
+	name := google.Underscore(t.Name)
+	for _, url := range []string{r.GetIdFormat(), r.SelfLinkUri(), r.CreateUri(), r.UpdateUri(), r.DeleteUri(), t.UpdateUrl} {
+		if slices.Contains(r.ExtractIdentifiers(url), name) {
+			errs.Add(path, "Property %s in resource %s can't be renamed_from, as it's used in the resource's URLs or id", t.Name, r.Name)
+			break
+		}
+	}
+
+	return errs
+}
+
+func (t *Type) validateLabelsField() ValidationErrors {
+	var errs ValidationErrors
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := t.Lineage()
@@ -1028,21 +1527,23 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Add(t.YamlPath(), "Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Add(t.YamlPath(), "Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Add(t.YamlPath(), "Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Add(t.YamlPath(), "Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
+
+	return errs
 }
 
 func (t Type) fieldMinVersion() string {
@@ -1229,8 +1730,28 @@ func (t *Type) ProviderOnly() bool {
 // exactly_one_of/at_least_one_of/etc to use camelcase, MM properities and
 // convert to snake in this method
 func (t *Type) GetPropertySchemaPath(schemaPath string) string {
-	nestedProps := t.ResourceMetadata.UserProperites()
+	r := t.ResourceMetadata
+	if path := propertySchemaPath(google.Concat(r.UserProperites(), google.Concat(r.UserParameters(), r.UserVirtualFields())), schemaPath); path != "" {
+		return path
+	}
+
+	// Otherwise the path may be relative to one of the field's ancestors, eg:
+	// the name of a sibling field. Fields within lists or maps can't be
+	// addressed this way, as their path depends on the element.
+	for parent := t.ParentMetadata; parent != nil; parent = parent.ParentMetadata {
+		prefix, ok := parent.schemaPathTokens()
+		if !ok {
+			break
+		}
+		if path := propertySchemaPath(parent.NestedProperties(), schemaPath); path != "" {
+			return strings.Join(append(prefix, path), ".0.")
+		}
+	}
+
+	return ""
+}
 
+func propertySchemaPath(nestedProps []*Type, schemaPath string) string {
 	var pathTkns []string
 	for _, pname := range strings.Split(schemaPath, ".0.") {
 		camelPname := google.Camelize(pname, "lower")
@@ -1264,6 +1785,21 @@ func (t *Type) GetPropertySchemaPath(schemaPath string) string {
 	return strings.Join(pathTkns[:], ".0.")
 }
 
+// Returns the tokens of the field's path in the schema, eg: [network_config
+// subnet], or false if the field is within a list or map.
+func (t *Type) schemaPathTokens() ([]string, bool) {
+	var tkns []string
+	for p := t; p != nil; p = p.ParentMetadata {
+		if p.IsA("Array") || p.IsA("Map") {
+			return nil, false
+		}
+		if !p.FlattenObject {
+			tkns = append([]string{google.Underscore(p.Name)}, tkns...)
+		}
+	}
+	return tkns, true
+}
+
 func (t Type) GetPropertySchemaPathList(propertyList []string) []string {
 	var list []string
 	for _, path := range propertyList {
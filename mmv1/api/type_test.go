@@ -1,7 +1,10 @@
 package api
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -337,6 +340,108 @@ func TestMetadataApiLineage(t *testing.T) {
 	}
 }
 
+func TestTypeGetPropertySchemaPath(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		Name:    "Resource",
+		BaseUrl: "projects/{{project}}/resources/{{name}}",
+		Parameters: []*Type{
+			{Name: "force_delete", Type: "Boolean", UrlParamOnly: true},
+		},
+		Properties: []*Type{
+			{Name: "name", Type: "String"},
+			{
+				Name: "config",
+				Type: "NestedObject",
+				Properties: []*Type{
+					{
+						Name: "source",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{Name: "repo", Type: "String"},
+							{Name: "bucketName", Type: "String"},
+						},
+					},
+				},
+			},
+			{
+				Name: "rules",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "NestedObject",
+					Properties: []*Type{
+						{Name: "action", Type: "String"},
+						{Name: "target", Type: "String"},
+					},
+				},
+			},
+		},
+	}
+	r.ProductMetadata = &Product{Name: "Product"}
+	r.SetDefault(r.ProductMetadata)
+
+	repo := r.Properties[1].Properties[0].Properties[0]
+	action := r.Properties[2].ItemType.Properties[0]
+
+	cases := []struct {
+		description string
+		obj         *Type
+		path        string
+		expected    string
+	}{
+		{
+			description: "full path",
+			obj:         repo,
+			path:        "config.0.source.0.bucket_name",
+			expected:    "config.0.source.0.bucket_name",
+		},
+		{
+			description: "sibling",
+			obj:         repo,
+			path:        "bucket_name",
+			expected:    "config.0.source.0.bucket_name",
+		},
+		{
+			description: "relative to an ancestor",
+			obj:         repo,
+			path:        "source.0.bucket_name",
+			expected:    "config.0.source.0.bucket_name",
+		},
+		{
+			description: "parameter",
+			obj:         repo,
+			path:        "force_delete",
+			expected:    "force_delete",
+		},
+		{
+			description: "missing field",
+			obj:         repo,
+			path:        "missing",
+			expected:    "",
+		},
+		{
+			description: "sibling within a list",
+			obj:         action,
+			path:        "target",
+			expected:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.obj.GetPropertySchemaPath(tc.path)
+			if got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestProviderOnly(t *testing.T) {
 	t.Parallel()
 
@@ -425,3 +530,302 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeRenamedFrom(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description   string
+		obj           *Type
+		conflicts     []string
+		exactlyOneOf  []string
+		dataKey       string
+		oldConflicts  []string
+		oldExactlyOne []string
+	}{
+		{
+			description: "not renamed",
+			obj:         &Type{Name: "tier", Type: "String"},
+			dataKey:     `"tier"`,
+		},
+		{
+			description:  "optional",
+			obj:          &Type{Name: "tier", Type: "String", RenamedFrom: "oldTier"},
+			conflicts:    []string{"old_tier"},
+			dataKey:      `tpgresource.RenamedFieldName(d, "tier", "old_tier")`,
+			oldConflicts: []string{"tier"},
+		},
+		{
+			description:   "required",
+			obj:           &Type{Name: "tier", Type: "String", Required: true, RenamedFrom: "oldTier"},
+			exactlyOneOf:  []string{"tier", "old_tier"},
+			dataKey:       `tpgresource.RenamedFieldName(d, "tier", "old_tier")`,
+			oldExactlyOne: []string{"tier", "old_tier"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.ResourceMetadata = &Resource{Name: "Widget", Properties: []*Type{tc.obj}}
+			if got, want := tc.obj.ConflictsWithPaths(), tc.conflicts; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected conflicts %v to be %v", got, want)
+			}
+			if got, want := tc.obj.ExactlyOneOfPaths(), tc.exactlyOneOf; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected exactly one of %v to be %v", got, want)
+			}
+			if got, want := tc.obj.ResourceDataKey(), tc.dataKey; got != want {
+				t.Errorf("expected key %s to be %s", got, want)
+			}
+
+			old := tc.obj.RenamedFromField()
+			if tc.obj.RenamedFrom == "" {
+				if old != nil {
+					t.Errorf("expected no deprecated field, got %s", old.Name)
+				}
+				return
+			}
+			if old.Name != tc.obj.RenamedFrom || old.Required || old.DeprecationMessage == "" {
+				t.Errorf("expected %s to be an optional deprecated field", old.Name)
+			}
+			if got, want := old.ConflictsWithPaths(), tc.oldConflicts; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected deprecated field conflicts %v to be %v", got, want)
+			}
+			if got, want := old.ExactlyOneOfPaths(), tc.oldExactlyOne; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected deprecated field exactly one of %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTypeValidateRenamedFrom(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		errors      int
+	}{
+		{
+			description: "top-level field",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "widgets",
+				Properties: []*Type{
+					{Name: "tier", Type: "String", RenamedFrom: "oldTier"},
+				},
+			},
+		},
+		{
+			description: "old name is taken",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "widgets",
+				Properties: []*Type{
+					{Name: "tier", Type: "String", RenamedFrom: "size"},
+					{Name: "size", Type: "String"},
+				},
+			},
+			errors: 1,
+		},
+		{
+			description: "used in the self link",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "widgets",
+				Properties: []*Type{
+					{Name: "name", Type: "String", RenamedFrom: "widgetName"},
+				},
+			},
+			errors: 1,
+		},
+		{
+			description: "default value",
+			resource: Resource{
+				Name:    "Widget",
+				BaseUrl: "widgets",
+				Properties: []*Type{
+					{Name: "tier", Type: "String", RenamedFrom: "oldTier", DefaultValue: "BASIC"},
+				},
+			},
+			errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			for _, p := range tc.resource.Properties {
+				p.ResourceMetadata = &tc.resource
+			}
+			if got, want := len(tc.resource.Properties[0].validateRenamedFrom()), tc.errors; got != want {
+				t.Errorf("expected %d errors to be %d", got, want)
+			}
+		})
+	}
+}
+
+func TestTypeReferenceTarget(t *testing.T) {
+	t.Parallel()
+
+	productsDir := t.TempDir()
+	files := map[string]string{
+		"example/product.yaml": "name: 'Example'\n",
+		"example/Hub.yaml":     "name: 'Hub'\nbase_url: 'projects/{{project}}/locations/global/hubs'\n",
+		"example/Group.yaml":   "name: 'Group'\nbase_url: 'projects/{{project}}/locations/global/hubs/{{hub}}/groups'\n",
+		"other/product.yaml":   "name: 'Other'\n",
+		"other/Network.yaml":   "name: 'Network'\nbase_url: 'projects/{{project}}/global/networks'\n",
+	}
+	overridesDir := t.TempDir()
+	files[filepath.Join(overridesDir, productsDir, "example/Hub.yaml")] = "base_url: 'projects/{{project}}/global/hubs'\n"
+	for name, content := range files {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(productsDir, name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		description  string
+		ref          *Type
+		format       string
+		normalizer   string
+		diffSuppress string
+		overrides    bool
+		yamlFiles    []string
+		errorPaths   []string
+	}{
+		{
+			description:  "resource of the product",
+			ref:          &Type{Name: "hub", Type: "ResourceRef", Target: "Hub", Imports: "name"},
+			format:       "relative_name",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/locations/global/hubs/{{name}}", "relative_name", "", d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			yamlFiles:    []string{"example/Spoke.yaml", "example/Hub.yaml"},
+		},
+		{
+			description:  "resource of the product with overrides",
+			ref:          &Type{Name: "hub", Type: "ResourceRef", Target: "Hub"},
+			format:       "relative_name",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/global/hubs/{{name}}", "relative_name", "", d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			overrides:    true,
+			yamlFiles:    []string{"example/Spoke.yaml", "example/Hub.yaml", "overrides/example/Hub.yaml"},
+		},
+		{
+			description:  "resource of another product as a self link",
+			ref:          &Type{Name: "network", Type: "ResourceRef", Target: "other/Network", Imports: "selfLink"},
+			format:       "self_link",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/global/networks/{{name}}", "self_link", config.OtherBasePath, d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			yamlFiles:    []string{"example/Spoke.yaml", "other/product.yaml", "other/Network.yaml"},
+		},
+		{
+			description:  "name",
+			ref:          &Type{Name: "network", Type: "ResourceRef", Target: "other/Network", ReferenceFormat: "name"},
+			format:       "name",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/global/networks/{{name}}", "name", "", d, config)`,
+			diffSuppress: "tpgresource.CompareResourceNames",
+		},
+		{
+			description:  "names that can't be completed",
+			ref:          &Type{Name: "group", Type: "ResourceRef", Target: "Group"},
+			format:       "relative_name",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/locations/global/hubs/{{hub}}/groups/{{name}}", "relative_name", "", d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			errorPaths:   []string{"properties.group"},
+		},
+		{
+			description:  "normalization excluded",
+			ref:          &Type{Name: "group", Type: "ResourceRef", Target: "Group", ExcludeReferenceNormalization: true},
+			format:       "relative_name",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/locations/global/hubs/{{hub}}/groups/{{name}}", "relative_name", "", d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+		},
+		{
+			description:  "invalid format",
+			ref:          &Type{Name: "hub", Type: "ResourceRef", Target: "Hub", ReferenceFormat: "uri"},
+			format:       "uri",
+			normalizer:   `tpgresource.NormalizeReference(v, "projects/{{project}}/locations/global/hubs/{{name}}", "uri", "", d, config)`,
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			errorPaths:   []string{"properties.hub"},
+		},
+		{
+			description:  "missing target",
+			ref:          &Type{Name: "hub", Type: "ResourceRef", Target: "Missing"},
+			format:       "relative_name",
+			diffSuppress: "tpgresource.CompareSelfLinkOrResourceName",
+			errorPaths:   []string{"properties.hub"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := Resource{
+				Name:    "Spoke",
+				BaseUrl: "projects/{{project}}/locations/{{location}}/spokes",
+				ProductMetadata: &Product{
+					Name:      "Example",
+					YamlFiles: []string{filepath.Join(productsDir, "example", "product.yaml")},
+				},
+				Properties: []*Type{tc.ref},
+				YamlFiles:  []string{filepath.Join(productsDir, "example", "Spoke.yaml")},
+			}
+			if tc.overrides {
+				r.ProductMetadata.OverrideDirectory = overridesDir
+			}
+			tc.ref.SetDefault(&r)
+
+			if tc.yamlFiles != nil {
+				var yamlFiles []string
+				for _, file := range r.YamlFiles {
+					if rel, err := filepath.Rel(filepath.Join(overridesDir, productsDir), file); err == nil && !strings.HasPrefix(rel, "..") {
+						file = filepath.Join("overrides", rel)
+					} else if rel, err := filepath.Rel(productsDir, file); err == nil {
+						file = rel
+					}
+					yamlFiles = append(yamlFiles, file)
+				}
+				if got, want := yamlFiles, tc.yamlFiles; !reflect.DeepEqual(got, want) {
+					t.Errorf("expected YAML files %v to be %v", got, want)
+				}
+			}
+
+			if got, want := tc.ref.ReferenceFormat, tc.format; got != want {
+				t.Errorf("expected reference format %q to be %q", got, want)
+			}
+			if tc.ref.TargetMetadata != nil {
+				if got, want := tc.ref.ReferenceNormalizer("v"), tc.normalizer; got != want {
+					t.Errorf("expected normalizer %s to be %s", got, want)
+				}
+			}
+			if got, want := tc.ref.ReferenceDiffSuppressFunc(), tc.diffSuppress; got != want {
+				t.Errorf("expected diff suppress func %s to be %s", got, want)
+			}
+
+			var paths []string
+			for _, err := range tc.ref.validateReference() {
+				paths = append(paths, err.Path)
+			}
+			if got, want := paths, tc.errorPaths; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected errors at %v to be at %v", got, want)
+			}
+		})
+	}
+}
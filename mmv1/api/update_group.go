@@ -0,0 +1,238 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// A group of top-level fields updated through a call of their own, after the
+// resource's main update. The fields of a group are sent together whenever
+// any of them changes. Groups are updated in the order they're listed in,
+// except that a group is always updated after the groups it depends on.
+type CustomUpdateGroup struct {
+	// Identifies the group in the `depends_on` of other groups.
+	Name string
+
+	// The names of the top-level fields the group updates.
+	Fields []string
+
+	// The URL and HTTP verb of the call updating the group.
+	UpdateUrl  string `yaml:"update_url"`
+	UpdateVerb string `yaml:"update_verb"`
+
+	// [Optional] The name of the resource's fingerprint field. The resource
+	// is read right before the call, and its fingerprint sent with it.
+	FingerprintName string `yaml:"fingerprint_name,omitempty"`
+
+	// [Optional] The names of the groups updated before this one.
+	DependsOn []string `yaml:"depends_on,omitempty"`
+
+	// [Optional] The state the resource must be in for the group to be
+	// updated. The resource is read right before the call, and the group is
+	// skipped if the precondition isn't met. The fields of a skipped group
+	// keep their prior values, so that their change is planned again.
+	Precondition *UpdatePrecondition `yaml:"precondition,omitempty"`
+}
+
+// The state a resource must be in for an update group to be updated. Exactly
+// one of `values` and `skip_values` is set.
+type UpdatePrecondition struct {
+	// The dot-separated path of the field holding the state of the resource,
+	// as returned by the API, such as `status`.
+	Path string
+
+	// The group is updated only while the field has one of these values.
+	Values []string `yaml:"values,omitempty"`
+
+	// The group is skipped while the field has one of these values.
+	SkipValues []string `yaml:"skip_values,omitempty"`
+}
+
+// Returns the Go condition under which the group is skipped, given the name
+// of the variable holding the state of the resource.
+func (p UpdatePrecondition) SkipCondition(state string) string {
+	values := p.SkipValues
+	negate := ""
+	if len(p.Values) > 0 {
+		values = p.Values
+		negate = "!"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%sslices.Contains([]string{%s}, %s)", negate, strings.Join(quoted, ", "), state)
+}
+
+// Sets the update URL, verb, ID and fingerprint of the fields of each update
+// group, so that the fields are grouped like fields with their own update
+// URLs. The group's name is used as the update ID. Fields with update URLs of
+// their own are left alone, and reported by validateUpdateGroups.
+func (r *Resource) setUpdateGroupDefaults() {
+	for _, g := range r.UpdateGroups {
+		for _, name := range g.Fields {
+			prop := r.updateGroupField(name)
+			if prop == nil || prop.UpdateUrl != "" || prop.UpdateId != "" {
+				continue
+			}
+			prop.UpdateUrl = g.UpdateUrl
+			prop.UpdateVerb = g.UpdateVerb
+			prop.UpdateId = g.Name
+			prop.FingerprintName = g.FingerprintName
+		}
+	}
+}
+
+func (r Resource) updateGroupField(name string) *Type {
+	for _, p := range r.AllUserProperties() {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the update group declared in `update_groups` that a group of fields
+// with their own update URLs belongs to, or nil if it isn't a declared group.
+func (r Resource) DeclaredUpdateGroup(group UpdateGroup) *CustomUpdateGroup {
+	for _, g := range r.UpdateGroups {
+		if g.Name == group.UpdateId && g.UpdateUrl == group.UpdateUrl && g.UpdateVerb == group.UpdateVerb {
+			return g
+		}
+	}
+	return nil
+}
+
+// Returns the declared update groups in the order they're updated: the order
+// they're listed in, with every group moved after the groups it depends on.
+// Groups in a dependency cycle are left out, which validateUpdateGroups
+// reports.
+func (r Resource) orderedUpdateGroups() []*CustomUpdateGroup {
+	var ordered []*CustomUpdateGroup
+	done := map[string]bool{}
+	for len(ordered) < len(r.UpdateGroups) {
+		progressed := false
+		for _, g := range r.UpdateGroups {
+			if done[g.Name] {
+				continue
+			}
+			ready := !slices.ContainsFunc(g.DependsOn, func(dep string) bool {
+				return !done[dep]
+			})
+			if ready {
+				ordered = append(ordered, g)
+				done[g.Name] = true
+				progressed = true
+				// Restart from the top, so that groups keep their listed
+				// order wherever their dependencies allow it.
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return ordered
+}
+
+// Orders groups of fields with their own update URLs: the groups of fields
+// that set those URLs themselves come first, in the given order, followed by
+// the declared update groups in the order they're updated.
+func (r Resource) orderUpdateGroups(groups []UpdateGroup) []UpdateGroup {
+	if len(r.UpdateGroups) == 0 {
+		return groups
+	}
+	ordered := google.Reject(groups, func(g UpdateGroup) bool {
+		return r.DeclaredUpdateGroup(g) != nil
+	})
+	for _, declared := range r.orderedUpdateGroups() {
+		for _, g := range groups {
+			if r.DeclaredUpdateGroup(g) == declared {
+				ordered = append(ordered, g)
+			}
+		}
+	}
+	return ordered
+}
+
+// Validates the update groups. Paths of the returned errors are relative to
+// the resource.
+func (r Resource) validateUpdateGroups() ValidationErrors {
+	var errs ValidationErrors
+	names := map[string]bool{}
+	grouped := map[string]string{}
+	for i, g := range r.UpdateGroups {
+		path := fmt.Sprintf("update_groups.%d", i)
+		if g.Name == "" {
+			errs.Add(path, "Missing `name` for update group")
+		} else if names[g.Name] {
+			errs.Add(joinYamlPath(path, "name"), "Update group name `%s` is used by more than one group", g.Name)
+		}
+		names[g.Name] = true
+
+		if g.UpdateUrl == "" {
+			errs.Add(path, "Missing `update_url` for update group `%s`", g.Name)
+		}
+		allowed := []string{"POST", "PUT", "PATCH"}
+		if !slices.Contains(allowed, g.UpdateVerb) {
+			errs.Add(joinYamlPath(path, "update_verb"), "Value on `update_verb` should be one of %#v", allowed)
+		}
+
+		if len(g.Fields) == 0 {
+			errs.Add(path, "Missing `fields` for update group `%s`", g.Name)
+		}
+		for j, name := range g.Fields {
+			fieldPath := joinYamlPath(path, fmt.Sprintf("fields.%d", j))
+			prop := r.updateGroupField(name)
+			switch {
+			case prop == nil:
+				errs.Add(fieldPath, "Update group `%s` lists `%s`, which isn't a top-level field of %s", g.Name, name, r.Name)
+			case grouped[name] != "":
+				errs.Add(fieldPath, "`%s` is in update groups `%s` and `%s`, but can only be in one", name, grouped[name], g.Name)
+			case prop.UpdateId != g.Name || prop.UpdateUrl != g.UpdateUrl:
+				errs.Add(fieldPath, "`%s` sets its own `update_url` or `update_id`, so it can't be in update group `%s`", name, g.Name)
+			case prop.Output || prop.Immutable || prop.UrlParamOnly:
+				errs.Add(fieldPath, "`%s` isn't updatable, so it can't be in update group `%s`", name, g.Name)
+			}
+			grouped[name] = g.Name
+		}
+
+		if g.Precondition != nil {
+			p := g.Precondition
+			if p.Path == "" {
+				errs.Add(joinYamlPath(path, "precondition"), "Missing `path` for the precondition of update group `%s`", g.Name)
+			}
+			if (len(p.Values) == 0) == (len(p.SkipValues) == 0) {
+				errs.Add(joinYamlPath(path, "precondition"), "Exactly one of `values` and `skip_values` must be set for the precondition of update group `%s`", g.Name)
+			}
+		}
+	}
+
+	for i, g := range r.UpdateGroups {
+		for j, dep := range g.DependsOn {
+			if !names[dep] || dep == g.Name {
+				errs.Add(joinYamlPath(fmt.Sprintf("update_groups.%d", i), fmt.Sprintf("depends_on.%d", j)), "Update group `%s` depends on `%s`, which isn't another update group", g.Name, dep)
+			}
+		}
+	}
+	if len(errs) == 0 && len(r.orderedUpdateGroups()) < len(r.UpdateGroups) {
+		errs.Add("update_groups", "The `depends_on` of update groups form a cycle")
+	}
+	return errs
+}
@@ -14,7 +14,7 @@
 package google
 
 import (
-	"log"
+	"fmt"
 
 	"gopkg.in/yaml.v2"
 )
@@ -22,8 +22,9 @@ import (
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	if err := yaml.UnmarshalStrict(content, obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+		return fmt.Errorf("cannot unmarshal data from file %s: %w", yamlPath, err)
 	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -15,9 +16,11 @@ import (
 	"time"
 
 	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
 )
 
@@ -45,11 +48,37 @@ var forceProvider = flag.String("provider", "", "optional provider name. If spec
 
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
+var openapiSync = flag.Bool("openapi-sync", false, "Update existing MMv1 YAML with the properties added to the openapi directory, keeping hand edits (Experimental)")
+
+// Example usage: --discovery-generate path/to/workflows_v1.json
+var discoveryGenerate = flag.String("discovery-generate", "", "Generate MMv1 YAML from the Discovery document at the given path (Experimental)")
+
 // Example usage: --yaml
 var yamlMode = flag.Bool("yaml", false, "copy text over from ruby yaml to go yaml")
 
 var showImportDiffs = flag.Bool("show-import-diffs", false, "write go import diffs to stdout")
 
+// Example usage: --incremental
+var incremental = flag.Bool("incremental", false, "skip resources whose product YAML and templates haven't changed since the last run into the same output path. Only supported by the default provider")
+
+// Example usage: --diff
+var diffOutput = flag.Bool("diff", false, "generate in memory and print a diff against the output path instead of writing to it")
+
+// Example usage: --output-format zip --output terraform-provider-google.zip
+var outputFormat = flag.String("output-format", "dir", "how to write the generated files: dir writes them to the output path, tar or zip write them to an archive at the output path")
+
+// Example usage: --validate-only
+var validateOnly = flag.Bool("validate-only", false, "load and validate product and resource YAML files without generating any files")
+
+// Example usage: --lint
+var lint = flag.Bool("lint", false, "load product and resource YAML files and report where they contradict the AIP conventions of resource-oriented APIs, without generating any files")
+
+// Example usage: --validation-report validation.json
+var validationReport = flag.String("validation-report", "", "optional path to write a JSON report of the product and resource YAML validation errors to")
+
+// Example usage: --record-schema-snapshots 7
+var recordSchemaSnapshots = flag.Int("record-schema-snapshots", 0, "record the schema of each resource at the given provider major version into its product's snapshots directory, without generating any files")
+
 func main() {
 
 	flag.Parse()
@@ -60,14 +89,33 @@ func main() {
 		return
 	}
 
-	if outputPath == nil || *outputPath == "" {
+	if *openapiSync {
+		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
+		parser.Sync()
+		return
+	}
+
+	if *discoveryGenerate != "" {
+		parser := openapi_generate.NewDiscoveryParser(*discoveryGenerate, "products")
+		parser.Run()
+		return
+	}
+
+	if !*validateOnly && !*lint && *recordSchemaSnapshots == 0 && (outputPath == nil || *outputPath == "") {
 		log.Printf("No output path specified, exiting")
 		return
 	}
 
 	if version == nil || *version == "" {
-		log.Printf("No version specified, assuming ga")
-		*version = "ga"
+		if *validateOnly || *lint {
+			// Validate every product, including those only available at the
+			// highest version
+			*version = provider.PRIVATE_VERSION
+			log.Printf("No version specified, assuming %s", *version)
+		} else {
+			log.Printf("No version specified, assuming ga")
+			*version = "ga"
+		}
 	}
 
 	var generateCode = !*doNotGenerateCode
@@ -131,6 +179,62 @@ func main() {
 		log.Fatalf("No product.yaml file found.")
 	}
 
+	if *validateOnly {
+		log.Printf("Validating %d product(s) at %s version", len(productsToGenerate), *version)
+		validationErrorsChannel := make(chan api.ValidationErrors, len(productsToGenerate))
+		for _, productFile := range productsToGenerate {
+			wg.Add(1)
+			go ValidateProduct(productFile, validationErrorsChannel, *overrideDirectory)
+		}
+		wg.Wait()
+		close(validationErrorsChannel)
+
+		var validationErrors api.ValidationErrors
+		for errs := range validationErrorsChannel {
+			validationErrors.Append(errs)
+		}
+		reportValidationErrors(validationErrors)
+		log.Printf("No validation errors found")
+		return
+	}
+
+	if *lint {
+		log.Printf("Linting %d product(s) at %s version", len(productsToGenerate), *version)
+		findingsChannel := make(chan api.ValidationErrors, len(productsToGenerate))
+		for _, productFile := range productsToGenerate {
+			wg.Add(1)
+			go LintProduct(productFile, findingsChannel, *resourceToGenerate, *overrideDirectory)
+		}
+		wg.Wait()
+		close(findingsChannel)
+
+		var findings api.ValidationErrors
+		for errs := range findingsChannel {
+			findings.Append(errs)
+		}
+		reportProblems(findings, "lint finding(s)")
+		log.Printf("No lint findings")
+		return
+	}
+
+	if *recordSchemaSnapshots > 0 {
+		log.Printf("Recording schema snapshots of %d product(s) at %s version for v%d", len(productsToGenerate), *version, *recordSchemaSnapshots)
+		validationErrorsChannel := make(chan api.ValidationErrors, len(productsToGenerate))
+		for _, productFile := range productsToGenerate {
+			wg.Add(1)
+			go RecordSchemaSnapshots(productFile, validationErrorsChannel, *resourceToGenerate, *overrideDirectory, *recordSchemaSnapshots)
+		}
+		wg.Wait()
+		close(validationErrorsChannel)
+
+		var validationErrors api.ValidationErrors
+		for errs := range validationErrorsChannel {
+			validationErrors.Append(errs)
+		}
+		reportValidationErrors(validationErrors)
+		return
+	}
+
 	startTime := time.Now()
 	providerName := "default (terraform)"
 	if *forceProvider != "" {
@@ -140,14 +244,58 @@ func main() {
 	log.Printf("Building %s version", *version)
 	log.Printf("Building %s provider", providerName)
 
+	var sink output.Sink = output.FileSystemSink{}
+	var rendered *output.MemorySink
+	if *diffOutput {
+		// Render at the paths of the output folder without writing to it, to
+		// diff against it once generation is done
+		rendered = output.NewMemorySink()
+		sink = rendered
+		log.Printf("Generating in memory to diff against '%s'", *outputPath)
+
+		if *incremental {
+			log.Printf("Incremental generation is not supported with --diff, ignoring --incremental")
+			*incremental = false
+		}
+	}
+
+	if *outputFormat != "dir" {
+		if *diffOutput || *incremental {
+			log.Fatalf("--output-format %s cannot be combined with --diff or --incremental", *outputFormat)
+		}
+
+		// The archive keeps the layout of an output folder named after it,
+		// as some files depend on the name of the output folder
+		archivePath := *outputPath
+		*outputPath = strings.TrimSuffix(archivePath, filepath.Ext(archivePath))
+		archiveSink, err := output.NewArchiveSink(archivePath, *outputPath, *outputFormat)
+		if err != nil {
+			log.Fatalf("Invalid --output-format: %v", err)
+		}
+		log.Printf("Writing generated files to the %s archive '%s'", *outputFormat, archivePath)
+		sink = archiveSink
+	}
+
+	if *incremental {
+		provider.EnableGenerationCache(*outputPath, fmt.Sprintf("provider=%s version=%s overrides=%s code=%t docs=%t", providerName, *version, *overrideDirectory, generateCode, generateDocs))
+	}
+
 	productsForVersionChannel := make(chan *api.Product, len(allProductFiles))
+	validationErrorsChannel := make(chan api.ValidationErrors, len(allProductFiles))
 	for _, productFile := range allProductFiles {
 		wg.Add(1)
-		go GenerateProduct(productFile, productsForVersionChannel, startTime, productsToGenerate, *resourceToGenerate, *overrideDirectory, generateCode, generateDocs)
+		go GenerateProduct(productFile, productsForVersionChannel, validationErrorsChannel, startTime, sink, productsToGenerate, *resourceToGenerate, *overrideDirectory, generateCode, generateDocs)
 	}
 	wg.Wait()
 
 	close(productsForVersionChannel)
+	close(validationErrorsChannel)
+
+	var validationErrors api.ValidationErrors
+	for errs := range validationErrorsChannel {
+		validationErrors.Append(errs)
+	}
+	reportValidationErrors(validationErrors)
 
 	var productsForVersion []*api.Product
 	for p := range productsForVersionChannel {
@@ -159,19 +307,190 @@ func main() {
 
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. Create an MMv1 provider with an arbitrary product (the first loaded).
-	providerToGenerate := newProvider(*forceProvider, *version, productsForVersion[0], startTime)
+	providerToGenerate := newProvider(*forceProvider, *version, productsForVersion[0], startTime, sink)
 	providerToGenerate.CopyCommonFiles(*outputPath, generateCode, generateDocs)
 
 	if generateCode {
 		providerToGenerate.CompileCommonFiles(*outputPath, productsForVersion, "")
 	}
 
-	provider.FixImports(*outputPath, *showImportDiffs)
+	provider.FixImports(sink, *outputPath, *showImportDiffs)
+	provider.SaveGenerationCache()
+
+	if err := sink.Close(); err != nil {
+		log.Fatalf("Cannot write the generated files: %v", err)
+	}
+
+	if rendered != nil {
+		if err := printOutputDiff(rendered, *outputPath, allProducts && *resourceToGenerate == ""); err != nil {
+			log.Fatalf("Cannot diff the generated output against %s: %v", *outputPath, err)
+		}
+	}
+}
+
+// Prints the differences between the existing output and the output
+// rendered by a --diff run. Files that are no longer generated can only be
+// detected when all products and resources were generated.
+func printOutputDiff(rendered *output.MemorySink, outputPath string, fullRun bool) error {
+	diff, err := output.DiffOutput(outputPath, rendered, func(path string, content []byte) bool {
+		return fullRun && (bytes.Contains(content, []byte("Type: MMv1")) || bytes.Contains(content, []byte("Type: Handwritten")))
+	})
+	if err != nil {
+		return err
+	}
+	if !fullRun {
+		log.Printf("Only generating part of the provider, removed files are not reported")
+	}
+
+	if diff.Empty() {
+		log.Printf("No differences found against %s", outputPath)
+		return nil
+	}
+	return diff.Write(os.Stdout)
+}
+
+// Reports every validation error found across all products at once: as text
+// in the log and, if requested, as JSON in the --validation-report file.
+// Exits if there were any errors.
+func reportValidationErrors(errs api.ValidationErrors) {
+	reportProblems(errs, "validation error(s)")
+}
+
+// Reports problems of any kind, such as validation errors or lint findings,
+// like reportValidationErrors.
+func reportProblems(errs api.ValidationErrors, kind string) {
+	errs.ResolvePositions()
+	errs.Sort()
+
+	if *validationReport != "" {
+		report, err := errs.JSON()
+		if err != nil {
+			log.Fatalf("Cannot build validation report: %v", err)
+		}
+		if err := os.WriteFile(*validationReport, report, 0644); err != nil {
+			log.Fatalf("Cannot write validation report %s: %v", *validationReport, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return
+	}
+
+	log.Fatalf("Found %d %s:\n%s", len(errs), kind, errs.Text())
+}
+
+// Loads a single product and records the current schema of its resources as
+// their snapshots at a provider major version, overwriting existing ones.
+func RecordSchemaSnapshots(productName string, validationErrorsChannel chan api.ValidationErrors, resourceToGenerate, overrideDirectory string, providerMajorVersion int) {
+	defer wg.Done()
+
+	productApi, errs := loadProduct(productName, overrideDirectory)
+	if len(errs) > 0 || productApi == nil {
+		validationErrorsChannel <- errs
+		return
+	}
+
+	for _, resource := range productApi.Objects {
+		if resource.IsExcluded() || (resourceToGenerate != "" && resource.Name != resourceToGenerate) {
+			continue
+		}
+
+		snapshot := resource.SchemaSnapshot()
+		snapshot.ProviderMajorVersion = providerMajorVersion
+		var content bytes.Buffer
+		encoder := yaml.NewEncoder(&content)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(snapshot); err != nil {
+			log.Fatalf("Cannot marshal the schema snapshot of %s: %v", resource.Name, err)
+		}
+
+		snapshotPath := api.SchemaSnapshotPath(filepath.Dir(resource.YamlFiles[0]), resource.Name, *version, providerMajorVersion)
+		if err := os.MkdirAll(filepath.Dir(snapshotPath), os.ModePerm); err != nil {
+			log.Fatalf("Cannot create the snapshots directory of %s: %v", productName, err)
+		}
+		if err := os.WriteFile(snapshotPath, content.Bytes(), 0644); err != nil {
+			log.Fatalf("Cannot write the schema snapshot %s: %v", snapshotPath, err)
+		}
+		log.Printf("Recorded the schema snapshot %s", snapshotPath)
+	}
+}
+
+// Loads, validates and generates a single product. Validation errors are sent
+// to validationErrorsChannel instead of stopping the run, and a product with
+// errors is neither generated nor sent to productsForVersionChannel.
+func GenerateProduct(productName string, productsForVersionChannel chan *api.Product, validationErrorsChannel chan api.ValidationErrors, startTime time.Time, sink output.Sink, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
+	defer wg.Done()
+
+	productApi, errs := loadProduct(productName, overrideDirectory)
+	if len(errs) > 0 {
+		log.Printf("%s: Found %d validation error(s), skipping generation", productName, len(errs))
+		validationErrorsChannel <- errs
+		return
+	}
+	if productApi == nil {
+		return
+	}
+
+	providerToGenerate := newProvider(*forceProvider, *version, productApi, startTime, sink)
+	productsForVersionChannel <- productApi
+
+	if !slices.Contains(productsToGenerate, productName) {
+		log.Printf("%s not specified, skipping generation", productName)
+		return
+	}
+
+	log.Printf("%s: Generating files", productName)
+
+	providerToGenerate.Generate(*outputPath, productName, resourceToGenerate, generateCode, generateDocs)
+}
+
+// Loads and validates a single product without generating it. On top of the
+// checks run during generation, this checks that the fields and templates
+// referenced by each resource exist.
+func ValidateProduct(productName string, validationErrorsChannel chan api.ValidationErrors, overrideDirectory string) {
+	defer wg.Done()
+
+	productApi, errs := loadProduct(productName, overrideDirectory)
+	if productApi != nil {
+		for _, resource := range productApi.Objects {
+			errs.Append(resource.ValidateReferences())
+		}
+	}
+
+	if len(errs) > 0 {
+		validationErrorsChannel <- errs
+	}
 }
 
-func GenerateProduct(productName string, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
+// Loads a single product and lints its resources against the AIP
+// conventions. Validation errors are reported instead if the product can't
+// be loaded.
+func LintProduct(productName string, findingsChannel chan api.ValidationErrors, resourceToLint, overrideDirectory string) {
 	defer wg.Done()
 
+	productApi, errs := loadProduct(productName, overrideDirectory)
+	if len(errs) > 0 || productApi == nil {
+		findingsChannel <- errs
+		return
+	}
+
+	var findings api.ValidationErrors
+	for _, resource := range productApi.Objects {
+		if resource.IsExcluded() || (resourceToLint != "" && resource.Name != resourceToLint) {
+			continue
+		}
+		// Resources with overrides are located in their override file
+		findings.Append(resource.Lint().InFile(resource.DefinitionYamlFile()))
+	}
+	findingsChannel <- findings
+}
+
+// Reads a product and its resources, including any overrides, then sets
+// their defaults and validates them. The product is nil if it doesn't exist
+// at the requested version or couldn't be read.
+func loadProduct(productName, overrideDirectory string) (*api.Product, api.ValidationErrors) {
+	var errs api.ValidationErrors
+
 	productYamlPath := path.Join(productName, "product.yaml")
 
 	var productOverridePath string
@@ -186,30 +505,41 @@ func GenerateProduct(productName string, productsForVersionChannel chan *api.Pro
 	overrideProductExists := !errors.Is(overrideProductErr, os.ErrNotExist)
 
 	if !(baseProductExists || overrideProductExists) {
-		log.Fatalf("%s does not contain a product.yaml file", productName)
+		errs = append(errs, &api.ValidationError{File: productName, Message: "does not contain a product.yaml file"})
+		return nil, errs
 	}
 
 	productApi := &api.Product{}
+	productFile := productYamlPath
 
 	if overrideProductExists {
+		productFile = productOverridePath
 		if baseProductExists {
-			api.Compile(productYamlPath, productApi, overrideDirectory)
+			errs.Append(api.Compile(productYamlPath, productApi, overrideDirectory))
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct, overrideDirectory)
+			errs.Append(api.Compile(productOverridePath, overrideApiProduct, overrideDirectory))
 
 			api.Merge(reflect.ValueOf(productApi), reflect.ValueOf(*overrideApiProduct))
+			productApi.YamlFiles = []string{productYamlPath, productOverridePath}
 		} else {
-			api.Compile(productOverridePath, productApi, overrideDirectory)
+			errs.Append(api.Compile(productOverridePath, productApi, overrideDirectory))
+			productApi.YamlFiles = []string{productOverridePath}
 		}
 	} else {
-		api.Compile(productYamlPath, productApi, overrideDirectory)
+		errs.Append(api.Compile(productYamlPath, productApi, overrideDirectory))
+		productApi.YamlFiles = []string{productYamlPath}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
 	}
+	productApi.OverrideDirectory = overrideDirectory
 
 	var resources []*api.Resource = make([]*api.Resource, 0)
 
 	if !productApi.ExistsAtVersionOrLower(*version) {
 		log.Printf("%s does not have a '%s' version, skipping", productName, *version)
-		return
+		return nil, errs
 	}
 
 	resourceFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productName))
@@ -233,13 +563,19 @@ func GenerateProduct(productName string, productsForVersionChannel chan *api.Pro
 		}
 
 		resource := &api.Resource{}
-		api.Compile(resourceYamlPath, resource, overrideDirectory)
+		if compileErrs := api.Compile(resourceYamlPath, resource, overrideDirectory); len(compileErrs) > 0 {
+			errs.Append(compileErrs)
+			continue
+		}
 		resource.SourceYamlFile = resourceYamlPath
+		resource.YamlFiles = []string{resourceYamlPath}
+		productApi.ApplyResourceDefaults(resource)
 
 		resource.TargetVersionName = *version
 		resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
 		resource.SetDefault(productApi)
-		resource.Validate()
+		errs.Append(resource.LoadSchemaSnapshots(*version))
+		errs.Append(resource.Validate())
 		resources = append(resources, resource)
 	}
 
@@ -260,19 +596,28 @@ func GenerateProduct(productName string, productsForVersionChannel chan *api.Pro
 			baseResourcePath := filepath.Join(productName, filepath.Base(overrideYamlPath))
 			_, baseResourceErr := os.Stat(baseResourcePath)
 			baseResourceExists := !errors.Is(baseResourceErr, os.ErrNotExist)
+			var compileErrs api.ValidationErrors
 			if baseResourceExists {
-				api.Compile(baseResourcePath, resource, overrideDirectory)
+				compileErrs.Append(api.Compile(baseResourcePath, resource, overrideDirectory))
 				overrideResource := &api.Resource{}
-				api.Compile(overrideYamlPath, overrideResource, overrideDirectory)
+				compileErrs.Append(api.Compile(overrideYamlPath, overrideResource, overrideDirectory))
 				api.Merge(reflect.ValueOf(resource), reflect.ValueOf(*overrideResource))
+				resource.YamlFiles = []string{baseResourcePath, overrideYamlPath}
 			} else {
-				api.Compile(overrideYamlPath, resource, overrideDirectory)
+				compileErrs.Append(api.Compile(overrideYamlPath, resource, overrideDirectory))
+				resource.YamlFiles = []string{overrideYamlPath}
+			}
+			if len(compileErrs) > 0 {
+				errs.Append(compileErrs)
+				continue
 			}
+			productApi.ApplyResourceDefaults(resource)
 
 			resource.TargetVersionName = *version
 			resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
 			resource.SetDefault(productApi)
-			resource.Validate()
+			errs.Append(resource.LoadSchemaSnapshots(*version))
+			errs.Append(resource.Validate().InFile(overrideYamlPath))
 			resources = append(resources, resource)
 		}
 
@@ -284,32 +629,22 @@ func GenerateProduct(productName string, productsForVersionChannel chan *api.Pro
 	}
 
 	productApi.Objects = resources
-	productApi.Validate()
-
-	providerToGenerate := newProvider(*forceProvider, *version, productApi, startTime)
-	productsForVersionChannel <- productApi
+	errs.Append(productApi.Validate().InFile(productFile))
 
-	if !slices.Contains(productsToGenerate, productName) {
-		log.Printf("%s not specified, skipping generation", productName)
-		return
-	}
-
-	log.Printf("%s: Generating files", productName)
-
-	providerToGenerate.Generate(*outputPath, productName, resourceToGenerate, generateCode, generateDocs)
+	return productApi, errs
 }
 
-func newProvider(providerName, version string, productApi *api.Product, startTime time.Time) provider.Provider {
+func newProvider(providerName, version string, productApi *api.Product, startTime time.Time, sink output.Sink) provider.Provider {
 	switch providerName {
 	case "tgc":
-		return provider.NewTerraformGoogleConversion(productApi, version, startTime)
+		return provider.NewTerraformGoogleConversion(productApi, version, startTime, sink)
 	case "tgc_cai2hcl":
-		return provider.NewCaiToTerraformConversion(productApi, version, startTime)
+		return provider.NewCaiToTerraformConversion(productApi, version, startTime, sink)
 	case "tgc_next":
-		return provider.NewTerraformGoogleConversionNext(productApi, version, startTime)
+		return provider.NewTerraformGoogleConversionNext(productApi, version, startTime, sink)
 	case "oics":
-		return provider.NewTerraformOiCS(productApi, version, startTime)
+		return provider.NewTerraformOiCS(productApi, version, startTime, sink)
 	default:
-		return provider.NewTerraform(productApi, version, startTime)
+		return provider.NewTerraform(productApi, version, startTime, sink)
 	}
 }
@@ -0,0 +1,529 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v2"
+)
+
+// DiscoveryParser generates MMv1 YAML from a Google API Discovery document,
+// as an alternative to the OpenAPI files read by Parser.
+type DiscoveryParser struct {
+	File   string
+	Output string
+}
+
+// A Discovery document, as described in
+// https://developers.google.com/discovery/v1/reference/apis. Only the parts
+// used to build products and resources are read.
+type discoveryDoc struct {
+	Name        string
+	Version     string
+	Title       string
+	RootUrl     string
+	ServicePath string
+	Auth        struct {
+		Oauth2 struct {
+			Scopes map[string]any
+		}
+	}
+	Schemas   map[string]*discoverySchema
+	Resources map[string]*discoveryResource
+}
+
+// A schema, or a parameter of a method, of a Discovery document.
+type discoverySchema struct {
+	Ref                  string `json:"$ref"`
+	Type                 string
+	Format               string
+	Description          string
+	Properties           map[string]*discoverySchema
+	AdditionalProperties *discoverySchema
+	Items                *discoverySchema
+	Enum                 []string
+	EnumDescriptions     []string
+	ReadOnly             bool
+	Annotations          struct {
+		// The ids of the methods that require the field
+		Required []string
+	}
+
+	// Where a parameter is sent: "path" or "query"
+	Location string
+	Required bool
+}
+
+type discoveryResource struct {
+	Methods   map[string]*discoveryMethod
+	Resources map[string]*discoveryResource
+}
+
+type discoveryMethod struct {
+	Id         string
+	Path       string
+	FlatPath   string
+	HttpMethod string
+	Parameters map[string]*discoverySchema
+	Request    *discoverySchema
+	Response   *discoverySchema
+}
+
+func NewDiscoveryParser(file, output string) DiscoveryParser {
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	if !filepath.IsAbs(file) {
+		file = path.Join(wd, file)
+	}
+
+	return DiscoveryParser{
+		File:   file,
+		Output: path.Join(wd, output),
+	}
+}
+
+func (parser DiscoveryParser) Run() {
+	log.Printf("Reading from file path %s", parser.File)
+
+	content, err := os.ReadFile(parser.File)
+	if err != nil {
+		log.Fatalf("error reading Discovery document %v", err)
+	}
+	doc := &discoveryDoc{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		log.Fatalf("error parsing Discovery document %s: %v", parser.File, err)
+	}
+
+	header, err := os.ReadFile("openapi_generate/header.txt")
+	if err != nil {
+		log.Fatalf("error reading header %v", err)
+	}
+
+	productPath := filepath.Join(parser.Output, doc.Name)
+	if err := os.MkdirAll(productPath, os.ModePerm); err != nil {
+		log.Fatalf("error creating product output directory %v: %v", productPath, err)
+	}
+
+	// Disables line wrap for long strings
+	yaml.FutureLineWrap()
+	writeYamlFile(filepath.Join(productPath, "product.yaml"), header, buildDiscoveryProduct(doc))
+	log.Printf("Generated product %+v/product.yaml", productPath)
+
+	for _, collection := range doc.collections() {
+		resource := buildDiscoveryResource(doc, collection)
+		resourcePath := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
+		writeYamlFile(resourcePath, header, resource)
+		log.Printf("Generated resource %s", resourcePath)
+	}
+}
+
+func writeYamlFile(filePath string, header []byte, obj any) {
+	bytes, err := yaml.Marshal(obj)
+	if err != nil {
+		log.Fatalf("error marshalling yaml %v: %v", filePath, err)
+	}
+	if err := os.WriteFile(filePath, append(header, bytes...), 0644); err != nil {
+		log.Fatalf("error writing file %v", err)
+	}
+}
+
+func buildDiscoveryProduct(doc *discoveryDoc) *api.Product {
+	apiProduct := &api.Product{}
+
+	// Standard titling is "Service Name API"
+	displayName := strings.TrimSuffix(doc.Title, " API")
+	apiProduct.Name = strings.ReplaceAll(displayName, " ", "")
+	apiProduct.DisplayName = displayName
+
+	apiProduct.Versions = []*product.Version{{
+		Name:    discoveryVersionName(doc.Version),
+		BaseUrl: doc.baseUrl(),
+	}}
+
+	scopes := slices.Sorted(maps.Keys(doc.Auth.Oauth2.Scopes))
+	if cloudPlatform := "https://www.googleapis.com/auth/cloud-platform"; len(scopes) == 0 || slices.Contains(scopes, cloudPlatform) {
+		scopes = []string{cloudPlatform}
+	}
+	apiProduct.Scopes = scopes
+
+	return apiProduct
+}
+
+// Returns the MMv1 version of an API version, such as "ga" for "v1" and
+// "beta" for "v1beta1".
+func discoveryVersionName(version string) string {
+	switch {
+	case strings.Contains(version, "alpha"):
+		return "alpha"
+	case strings.Contains(version, "beta"):
+		return "beta"
+	default:
+		return "ga"
+	}
+}
+
+// Returns the base URL of the API, including its version. Most APIs have no
+// service path and prefix the paths of their methods with the version.
+func (doc *discoveryDoc) baseUrl() string {
+	if doc.ServicePath == "" {
+		return fmt.Sprintf("%s%s/", doc.RootUrl, doc.Version)
+	}
+	return doc.RootUrl + doc.ServicePath
+}
+
+var discoveryParamRegex = regexp.MustCompile(`\{\+?(\w+)\}`)
+
+// Converts the path of a method, such as
+// "v1/projects/{projectsId}/locations/{locationsId}/workflows", to a URL
+// relative to the product base URL, such as
+// "projects/{{project}}/locations/{{location}}/workflows".
+func (doc *discoveryDoc) url(method *discoveryMethod) string {
+	p := method.FlatPath
+	if p == "" {
+		p = method.Path
+	}
+	p = strings.TrimPrefix(p, doc.Version+"/")
+	return discoveryParamRegex.ReplaceAllStringFunc(p, func(match string) string {
+		return fmt.Sprintf("{{%s}}", discoveryParamName(discoveryParamRegex.FindStringSubmatch(match)[1]))
+	})
+}
+
+// Flat paths name the segments of resource names after their collection,
+// such as projectsId. MMv1 uses the singular name, such as project.
+func discoveryParamName(name string) string {
+	if collection, ok := strings.CutSuffix(name, "sId"); ok {
+		name = collection
+		if singular, ok := strings.CutSuffix(collection, "ie"); ok {
+			name = singular + "y"
+		}
+	}
+	return google.Underscore(name)
+}
+
+// Returns the collections of the document that can be managed as resources,
+// which are those with a method creating their items.
+func (doc *discoveryDoc) collections() []*discoveryResource {
+	var collections []*discoveryResource
+	var walk func(resources map[string]*discoveryResource)
+	walk = func(resources map[string]*discoveryResource) {
+		for _, k := range slices.Sorted(maps.Keys(resources)) {
+			collection := resources[k]
+			if create := collection.method("create", "insert"); create != nil && create.HttpMethod == "POST" && create.Request != nil && create.Request.Ref != "" {
+				collections = append(collections, collection)
+			}
+			walk(collection.Resources)
+		}
+	}
+	walk(doc.Resources)
+	return collections
+}
+
+// Returns the first of the named methods the collection has.
+func (collection *discoveryResource) method(names ...string) *discoveryMethod {
+	for _, name := range names {
+		if m, ok := collection.Methods[name]; ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// Returns true if the method returns a long-running operation (AIP-151).
+func (doc *discoveryDoc) returnsOperation(method *discoveryMethod) bool {
+	if method == nil || method.Response == nil {
+		return false
+	}
+	schema, ok := doc.Schemas[method.Response.Ref]
+	if !ok {
+		return false
+	}
+	done, ok := schema.Properties["done"]
+	return ok && done.Type == "boolean"
+}
+
+func buildDiscoveryResource(doc *discoveryDoc, collection *discoveryResource) api.Resource {
+	resource := api.Resource{}
+
+	create := collection.method("create", "insert")
+	schema := doc.Schemas[create.Request.Ref]
+	resource.Name = create.Request.Ref
+	resource.Description = "Description"
+	if schema != nil && strings.TrimSpace(schema.Description) != "" {
+		resource.Description = trimDescription(schema.Description)
+	}
+
+	baseUrl := doc.url(create)
+	resource.BaseUrl = baseUrl
+
+	// The id of the resource is either sent as a query parameter of the
+	// create method, such as workflowId, or as the name in the request body.
+	id := "name"
+	var parameters []*api.Type
+	for _, k := range slices.Sorted(maps.Keys(create.Parameters)) {
+		param := create.Parameters[k]
+		if param.Location != "query" || !strings.HasSuffix(k, "Id") || k == "requestId" {
+			continue
+		}
+		id = google.Underscore(k)
+		resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, k, id)
+
+		description := param.Description
+		if strings.TrimSpace(description) == "" {
+			description = "No description"
+		}
+		parameters = append(parameters, &api.Type{
+			Name:         k,
+			Type:         "String",
+			Description:  trimDescription(description),
+			Required:     true,
+			Immutable:    true,
+			UrlParamOnly: true,
+		})
+		break
+	}
+
+	// Placeholders of the base URL other than project are parameters, such
+	// as location or the name of a parent resource
+	var urlParameters []*api.Type
+	for _, match := range regexp.MustCompile(`\{\{(\w+)\}\}`).FindAllStringSubmatch(baseUrl, -1) {
+		if match[1] == "project" {
+			continue
+		}
+		urlParameters = append(urlParameters, &api.Type{
+			Name:         google.Camelize(match[1], "lower"),
+			Type:         "String",
+			Description:  fmt.Sprintf("The %s of the resource.", strings.ReplaceAll(match[1], "_", " ")),
+			Required:     true,
+			Immutable:    true,
+			UrlParamOnly: true,
+		})
+	}
+	resource.Parameters = append(urlParameters, parameters...)
+
+	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, id)
+	if get := collection.method("get"); get != nil {
+		getUrl := doc.url(get)
+		if i := strings.LastIndex(getUrl, "{{"); i >= 0 {
+			selfLink = fmt.Sprintf("%s{{%s}}", getUrl[:i], id)
+		}
+	}
+	resource.SelfLink = selfLink
+	resource.IdFormat = selfLink
+	resource.ImportFormat = []string{selfLink}
+
+	if schema != nil {
+		resource.Properties = doc.properties(schema, create.Id, []string{create.Request.Ref})
+	}
+	if id != "name" {
+		// The name is set by the API from the id
+		for _, p := range resource.Properties {
+			if p.Name == "name" {
+				p.Output = true
+				p.Required = false
+			}
+		}
+	}
+
+	update := collection.method("patch", "update")
+	if update != nil {
+		resource.UpdateVerb = update.HttpMethod
+		if param, ok := update.Parameters["updateMask"]; ok && param.Location == "query" {
+			resource.UpdateMask = true
+		}
+	} else {
+		resource.Immutable = true
+	}
+
+	var asyncActions []string
+	for _, action := range []struct {
+		name   string
+		method *discoveryMethod
+	}{
+		{"create", create},
+		{"delete", collection.method("delete")},
+		{"update", update},
+	} {
+		if doc.returnsOperation(action.method) {
+			asyncActions = append(asyncActions, action.name)
+		}
+	}
+	if len(asyncActions) > 0 {
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = asyncActions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = true
+		resource.Async = async
+	}
+
+	example := r.Examples{}
+	example.Name = "name_of_example_file"
+	example.PrimaryResourceId = "example"
+	example.Vars = map[string]string{"resource_name": "test-resource"}
+
+	resource.Examples = []r.Examples{example}
+
+	// Write the status as an encoded string to flag when a YAML file has been
+	// copy and pasted without actually using this tool
+	resource.AutogenStatus = base64.StdEncoding.EncodeToString([]byte(resource.Name))
+
+	return resource
+}
+
+// Builds the properties of a schema. createId is the id of the method
+// creating the resource, used to find required fields, and refs are the
+// schemas being built, used to skip recursive fields.
+func (doc *discoveryDoc) properties(schema *discoverySchema, createId string, refs []string) []*api.Type {
+	properties := []*api.Type{}
+	for _, k := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if field := doc.property(k, schema.Properties[k], createId, refs); field != nil {
+			properties = append(properties, field)
+		}
+	}
+	return properties
+}
+
+func (doc *discoveryDoc) property(name string, schema *discoverySchema, createId string, refs []string) *api.Type {
+	field := doc.fieldType(name, schema, createId, refs)
+	if field == nil {
+		return nil
+	}
+	field.Name = name
+
+	description := schema.Description
+	if ref, ok := doc.Schemas[schema.Ref]; ok && strings.TrimSpace(description) == "" {
+		description = ref.Description
+	}
+	if schema.ReadOnly || strings.HasPrefix(description, "Output only.") {
+		field.Output = true
+	}
+	if strings.HasPrefix(description, "Immutable.") {
+		field.Immutable = true
+	}
+	if slices.Contains(schema.Annotations.Required, createId) || strings.HasPrefix(description, "Required.") {
+		field.Required = !field.Output
+	}
+
+	// Describe the values of enums, which the documentation only lists
+	var values []string
+	for i, value := range schema.Enum {
+		if slices.Contains(field.EnumValues, value) && i < len(schema.EnumDescriptions) && schema.EnumDescriptions[i] != "" {
+			values = append(values, fmt.Sprintf("* %s: %s", value, schema.EnumDescriptions[i]))
+		}
+	}
+	if len(values) > 0 {
+		description = fmt.Sprintf("%s\nPossible values:\n%s", description, strings.Join(values, "\n"))
+	}
+	if strings.TrimSpace(description) == "" {
+		description = "No description"
+	}
+	field.Description = trimDescription(description)
+
+	return field
+}
+
+// Returns the field for a schema, without its name and annotations, or nil
+// for recursive schemas, which can't be represented in Terraform.
+func (doc *discoveryDoc) fieldType(name string, schema *discoverySchema, createId string, refs []string) *api.Type {
+	field := &api.Type{}
+
+	if schema.Ref != "" {
+		if slices.Contains(refs, schema.Ref) {
+			log.Printf("Skipping recursive field %s of type %s", name, schema.Ref)
+			return nil
+		}
+		ref, ok := doc.Schemas[schema.Ref]
+		if !ok {
+			log.Printf("Skipping field %s of unknown type %s", name, schema.Ref)
+			return nil
+		}
+		field.Type = "NestedObject"
+		field.Properties = doc.properties(ref, createId, append(slices.Clone(refs), schema.Ref))
+		return field
+	}
+
+	switch schema.Type {
+	case "string":
+		field.Type = "String"
+		for _, value := range schema.Enum {
+			if strings.HasSuffix(value, "_UNSPECIFIED") {
+				continue
+			}
+			field.Type = "Enum"
+			field.EnumValues = append(field.EnumValues, value)
+		}
+	case "integer":
+		field.Type = "Integer"
+	case "number":
+		field.Type = "Double"
+	case "boolean":
+		field.Type = "Boolean"
+	case "object":
+		switch {
+		case name == "labels":
+			// Standard labels implementation
+			field.Type = "KeyValueLabels"
+		case name == "annotations":
+			field.Type = "KeyValueAnnotations"
+		case schema.AdditionalProperties != nil && schema.AdditionalProperties.Type == "string":
+			// AdditionalProperties with type string is a string -> string map
+			field.Type = "KeyValuePairs"
+		case schema.AdditionalProperties != nil:
+			value := doc.fieldType(name, schema.AdditionalProperties, createId, refs)
+			if value == nil || value.Type != "NestedObject" {
+				field.Type = "KeyValuePairs"
+				break
+			}
+			field.Type = "Map"
+			field.KeyName = "name"
+			field.ValueType = value
+		default:
+			field.Type = "NestedObject"
+			field.Properties = doc.properties(schema, createId, refs)
+		}
+	case "array":
+		if schema.Items == nil {
+			return nil
+		}
+		itemType := doc.fieldType(name, schema.Items, createId, refs)
+		if itemType == nil {
+			return nil
+		}
+		field.Type = "Array"
+		field.ItemType = itemType
+	default:
+		// Fields of type "any" hold arbitrary JSON
+		field.Type = "String"
+	}
+
+	return field
+}
@@ -0,0 +1,206 @@
+package openapi_generate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testDiscoveryDoc = `{
+  "name": "workflows",
+  "version": "v1",
+  "title": "Workflows API",
+  "rootUrl": "https://workflows.googleapis.com/",
+  "servicePath": "",
+  "auth": {"oauth2": {"scopes": {"https://www.googleapis.com/auth/cloud-platform": {}}}},
+  "schemas": {
+    "Workflow": {
+      "id": "Workflow",
+      "type": "object",
+      "description": "Workflow program to be executed by Workflows.",
+      "properties": {
+        "name": {"type": "string", "description": "The resource name of the workflow."},
+        "description": {"type": "string", "description": "Description of the workflow provided by the user."},
+        "state": {
+          "type": "string",
+          "readOnly": true,
+          "description": "Output only. State of the workflow deployment.",
+          "enum": ["STATE_UNSPECIFIED", "ACTIVE", "UNAVAILABLE"],
+          "enumDescriptions": ["Invalid state.", "The workflow has been deployed successfully.", ""]
+        },
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "sourceContents": {
+          "type": "string",
+          "annotations": {"required": ["workflows.projects.locations.workflows.create"]}
+        },
+        "stateError": {"$ref": "StateError", "readOnly": true},
+        "secrets": {"type": "object", "additionalProperties": {"$ref": "Secret"}},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "StateError": {
+      "id": "StateError",
+      "type": "object",
+      "description": "Describes an error related to the current state of the workflow.",
+      "properties": {
+        "details": {"type": "string"},
+        "cause": {"$ref": "StateError"}
+      }
+    },
+    "Secret": {
+      "id": "Secret",
+      "type": "object",
+      "properties": {
+        "version": {"type": "integer"}
+      }
+    },
+    "Operation": {
+      "id": "Operation",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "done": {"type": "boolean"}
+      }
+    },
+    "Empty": {"id": "Empty", "type": "object", "properties": {}}
+  },
+  "resources": {
+    "projects": {
+      "resources": {
+        "locations": {
+          "methods": {
+            "get": {"id": "workflows.projects.locations.get", "flatPath": "v1/projects/{projectsId}/locations/{locationsId}", "path": "v1/{+name}", "httpMethod": "GET"}
+          },
+          "resources": {
+            "workflows": {
+              "methods": {
+                "create": {
+                  "id": "workflows.projects.locations.workflows.create",
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/workflows",
+                  "path": "v1/{+parent}/workflows",
+                  "httpMethod": "POST",
+                  "parameters": {
+                    "parent": {"location": "path", "required": true, "type": "string"},
+                    "workflowId": {"location": "query", "type": "string", "description": "Required. The ID of the workflow to be created."}
+                  },
+                  "request": {"$ref": "Workflow"},
+                  "response": {"$ref": "Operation"}
+                },
+                "get": {
+                  "id": "workflows.projects.locations.workflows.get",
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/workflows/{workflowsId}",
+                  "path": "v1/{+name}",
+                  "httpMethod": "GET",
+                  "response": {"$ref": "Workflow"}
+                },
+                "patch": {
+                  "id": "workflows.projects.locations.workflows.patch",
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/workflows/{workflowsId}",
+                  "path": "v1/{+name}",
+                  "httpMethod": "PATCH",
+                  "parameters": {
+                    "updateMask": {"location": "query", "type": "string", "format": "google-fieldmask"}
+                  },
+                  "request": {"$ref": "Workflow"},
+                  "response": {"$ref": "Operation"}
+                },
+                "delete": {
+                  "id": "workflows.projects.locations.workflows.delete",
+                  "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/workflows/{workflowsId}",
+                  "path": "v1/{+name}",
+                  "httpMethod": "DELETE",
+                  "response": {"$ref": "Operation"}
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestBuildDiscoveryResource(t *testing.T) {
+	t.Parallel()
+
+	doc := &discoveryDoc{}
+	if err := json.Unmarshal([]byte(testDiscoveryDoc), doc); err != nil {
+		t.Fatal(err)
+	}
+
+	p := buildDiscoveryProduct(doc)
+	if got, want := p.Name, "Workflows"; got != want {
+		t.Errorf("expected product name %q to be %q", got, want)
+	}
+	if got, want := p.Versions[0].Name, "ga"; got != want {
+		t.Errorf("expected version %q to be %q", got, want)
+	}
+	if got, want := p.Versions[0].BaseUrl, "https://workflows.googleapis.com/v1/"; got != want {
+		t.Errorf("expected base url %q to be %q", got, want)
+	}
+
+	collections := doc.collections()
+	if len(collections) != 1 {
+		t.Fatalf("expected 1 collection, got %d", len(collections))
+	}
+	res := buildDiscoveryResource(doc, collections[0])
+
+	for _, tc := range []struct {
+		description string
+		got, want   any
+	}{
+		{"name", res.Name, "Workflow"},
+		{"base url", res.BaseUrl, "projects/{{project}}/locations/{{location}}/workflows"},
+		{"self link", res.SelfLink, "projects/{{project}}/locations/{{location}}/workflows/{{workflow_id}}"},
+		{"create url", res.CreateUrl, "projects/{{project}}/locations/{{location}}/workflows?workflowId={{workflow_id}}"},
+		{"update verb", res.UpdateVerb, "PATCH"},
+		{"update mask", res.UpdateMask, true},
+		{"async actions", res.Async.Actions, []string{"create", "delete", "update"}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("expected %s %v to be %v", tc.description, tc.got, tc.want)
+		}
+	}
+
+	var parameters []string
+	for _, p := range res.Parameters {
+		parameters = append(parameters, p.Name)
+	}
+	if got, want := parameters, []string{"location", "workflowId"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected parameters %v to be %v", got, want)
+	}
+
+	properties := map[string]string{}
+	for _, p := range res.Properties {
+		properties[p.Name] = p.Type
+		if got, want := p.Output, p.Name == "name" || p.Name == "state" || p.Name == "stateError"; got != want {
+			t.Errorf("expected output %v of %s to be %v", got, p.Name, want)
+		}
+		switch p.Name {
+		case "sourceContents":
+			if !p.Required {
+				t.Errorf("expected %s to be required", p.Name)
+			}
+		case "state":
+			if got, want := p.EnumValues, []string{"ACTIVE", "UNAVAILABLE"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected enum values %v to be %v", got, want)
+			}
+		case "stateError":
+			if got, want := len(p.Properties), 1; got != want {
+				t.Errorf("expected %d properties of %s, without the recursive field, to be %d", got, p.Name, want)
+			}
+		}
+	}
+	if got, want := properties, map[string]string{
+		"name":           "String",
+		"description":    "String",
+		"state":          "Enum",
+		"labels":         "KeyValueLabels",
+		"sourceContents": "String",
+		"stateError":     "NestedObject",
+		"secrets":        "Map",
+		"tags":           "Array",
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected property types %v to be %v", got, want)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"maps"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -57,10 +58,16 @@ func NewOpenapiParser(folder, output string) Parser {
 }
 
 func (parser Parser) Run() {
+	for _, file := range parser.files() {
+		parser.WriteYaml(file)
+	}
+}
+
+// Returns the paths of the OpenAPI files in the parser's folder.
+func (parser Parser) files() []string {
 	f, err := os.Open(parser.Folder)
 	if err != nil {
 		log.Fatalf(err.Error())
-		return
 	}
 	defer f.Close()
 	files, err := f.Readdirnames(0)
@@ -73,9 +80,11 @@ func (parser Parser) Run() {
 		log.Fatalf("No OpenAPI files found in %s", parser.Folder)
 	}
 
+	var paths []string
 	for _, file := range files {
-		parser.WriteYaml(path.Join(parser.Folder, file))
+		paths = append(paths, path.Join(parser.Folder, file))
 	}
+	return paths
 }
 
 func (parser Parser) WriteYaml(filePath string) {
@@ -300,7 +309,7 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 		if strings.Contains(strings.ToLower(param.Value.Name), strings.ToLower(resourceName)) {
 			idParam = param.Value.Name
 		}
-		paramObj := writeObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
+		paramObj := writeObject(param.Value.Name, "", param.Value.Schema, propType(param.Value.Schema), true, false)
 		description := param.Value.Description
 		if strings.TrimSpace(description) == "" {
 			description = "No description"
@@ -316,7 +325,7 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 		parameters = append(parameters, &paramObj)
 	}
 
-	properties := buildProperties(path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties, path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Required)
+	properties := buildProperties(path.Post.RequestBody.Value.Content["application/json"].Schema.Value, "", false)
 
 	returnArray = append(returnArray, parameters)
 	returnArray = append(returnArray, properties)
@@ -326,14 +335,26 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 }
 
 func propType(prop *openapi3.SchemaRef) openapi3.Types {
-	if len(prop.Value.AllOf) > 0 {
-		return *prop.Value.AllOf[0].Value.Type
-	} else {
-		return *prop.Value.Type
+	switch {
+	case len(prop.Value.AllOf) > 0:
+		return propType(prop.Value.AllOf[0])
+	case prop.Value.Type == nil && len(prop.Value.OneOf) > 0:
+		// A field holding one of several schemas is mapped to the first one
+		return propType(prop.Value.OneOf[0])
+	case prop.Value.Type == nil && len(prop.Value.Properties) > 0:
+		return openapi3.Types{"object"}
+	case prop.Value.Type == nil:
+		// Arbitrary JSON values are sent as strings
+		return openapi3.Types{"string"}
 	}
+	return *prop.Value.Type
 }
 
-func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam bool) api.Type {
+// Builds the MMv1 field for a schema. lineage is the Terraform path of the
+// object containing the field, like "network_config.0.", and inList is set
+// when that object is in a list or a map, where fields can't be referenced
+// by their path.
+func writeObject(name, lineage string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam, inList bool) api.Type {
 	var field api.Type
 
 	switch name {
@@ -344,113 +365,241 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	case "locationsId":
 		name = "location"
 	}
-	additionalDescription := ""
 
 	if len(obj.Value.AllOf) > 0 {
 		obj = obj.Value.AllOf[0]
-		objType = *obj.Value.Type
+		objType = propType(obj)
+	} else if obj.Value.Type == nil && len(obj.Value.OneOf) > 0 {
+		obj = obj.Value.OneOf[0]
+		objType = propType(obj)
 	}
 
 	field.Name = name
+	additionalDescription := setType(&field, obj.Value, objType, fmt.Sprintf("%s%s.0.", lineage, google.Underscore(name)), inList)
+
+	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
+	if strings.TrimSpace(description) == "" {
+		description = "No description"
+	}
+
+	field.Description = trimDescription(description)
+
+	if urlParam {
+		field.UrlParamOnly = true
+		field.Required = true
+	}
+
+	fieldBehavior(&field, obj)
+
+	return field
+}
+
+// Sets the type of a field, and of its nested fields, from its schema, and
+// returns a description of the format of the value, if any.
+func setType(field *api.Type, schema *openapi3.Schema, objType openapi3.Types, lineage string, inList bool) string {
+	additionalDescription := ""
+
 	switch objType[0] {
 	case "string":
 		field.Type = "String"
-		if len(obj.Value.Enum) > 0 {
-			var enums []string
-			for _, enum := range obj.Value.Enum {
+		switch schema.Format {
+		case "int64", "uint64":
+			// 64-bit integers are encoded as strings in JSON
+			field.Type = "Integer"
+		case "date-time", "google-datetime":
+			additionalDescription = "A timestamp in RFC3339 UTC \"Zulu\" format, with nanosecond resolution and up to nine fractional digits. Examples: \"2014-10-02T15:01:23Z\" and \"2014-10-02T15:01:23.045123456Z\"."
+		case "google-duration":
+			additionalDescription = "A duration in seconds with up to nine fractional digits, terminated by 's'. Example: \"3.5s\"."
+		case "byte":
+			additionalDescription = "A base64-encoded string."
+		case "password":
+			field.Sensitive = true
+		}
+
+		if len(schema.Enum) > 0 {
+			field.Type = "Enum"
+			for _, enum := range schema.Enum {
 				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
 					continue
 				}
-				enums = append(enums, fmt.Sprintf("%v", enum))
+				field.EnumValues = append(field.EnumValues, fmt.Sprintf("%v", enum))
 			}
-			additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
+			break
+		}
+
+		if field.Type == "Integer" {
+			field.Validation.Function = rangeValidation("Int", schema)
+		} else {
+			field.Validation.Function = lengthValidation(schema)
 		}
 	case "integer":
 		field.Type = "Integer"
+		field.Validation.Function = rangeValidation("Int", schema)
 	case "number":
 		field.Type = "Double"
+		field.Validation.Function = rangeValidation("Float", schema)
 	case "boolean":
 		field.Type = "Boolean"
 	case "object":
-		if field.Name == "labels" {
+		switch {
+		case field.Name == "labels":
 			// Standard labels implementation
 			field.Type = "KeyValueLabels"
-			break
-		}
-
-		if obj.Value.AdditionalProperties.Schema != nil && obj.Value.AdditionalProperties.Schema.Value.Type.Is("string") {
+		case field.Name == "annotations":
+			field.Type = "KeyValueAnnotations"
+		case schema.AdditionalProperties.Schema != nil && propType(schema.AdditionalProperties.Schema)[0] == "string":
 			// AdditionalProperties with type string is a string -> string map
 			field.Type = "KeyValuePairs"
-			break
+		case schema.AdditionalProperties.Schema != nil && propType(schema.AdditionalProperties.Schema)[0] == "object":
+			// AdditionalProperties with type object is a map of objects keyed
+			// by name
+			value := schema.AdditionalProperties.Schema
+			field.Type = "Map"
+			field.KeyName = "name"
+			if _, ok := value.Value.Properties["name"]; ok {
+				field.KeyName = "key"
+			}
+			field.KeyDescription = fmt.Sprintf("The key of the %s entry.", google.Underscore(field.Name))
+			field.ValueType = &api.Type{
+				Name:       field.Name,
+				Type:       "NestedObject",
+				Properties: buildProperties(value.Value, "", true),
+			}
+		default:
+			field.Type = "NestedObject"
+			field.Properties = buildProperties(schema, lineage, inList)
 		}
-
-		field.Type = "NestedObject"
-
-		field.Properties = buildProperties(obj.Value.Properties, obj.Value.Required)
 	case "array":
 		field.Type = "Array"
 		var subField api.Type
-		typ := *obj.Value.Items.Value.Type
-		switch typ[0] {
-		case "string":
-			subField.Type = "String"
-		case "integer":
-			subField.Type = "Integer"
-		case "number":
-			subField.Type = "Double"
-		case "boolean":
-			subField.Type = "Boolean"
-		case "object":
-			subField.Type = "NestedObject"
-			subField.Properties = buildProperties(obj.Value.Items.Value.Properties, obj.Value.Items.Value.Required)
-		}
+		additionalDescription = setType(&subField, schema.Items.Value, propType(schema.Items), "", true)
+		// Validations of the items are set on the list
+		field.ItemValidation, subField.Validation = subField.Validation, r.Validation{}
 		field.ItemType = &subField
 	default:
 		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
 	}
 
-	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
-	if strings.TrimSpace(description) == "" {
-		description = "No description"
-	}
-
-	field.Description = trimDescription(description)
+	return additionalDescription
+}
 
-	if urlParam {
-		field.UrlParamOnly = true
-		field.Required = true
+// Sets the behavior of a field from the OpenAPI attributes of its schema,
+// and the x-google-* annotations for the field behaviors described by AIP 203.
+func fieldBehavior(field *api.Type, obj *openapi3.SchemaRef) {
+	hasExtension := func(name string) bool {
+		value, err := obj.JSONLookup(name)
+		return err == nil && value != nil && value != false
 	}
 
 	// These methods are only available when the field is set
-	if obj.Value.ReadOnly {
+	if obj.Value.ReadOnly || hasExtension("x-google-output-only") {
 		field.Output = true
 	}
 
 	// x-google-identifier fields are described by AIP 203 and are represented
 	// as output only in Terraform.
-	xGoogleId, err := obj.JSONLookup("x-google-identifier")
-	if err == nil && xGoogleId != nil {
+	if hasExtension("x-google-identifier") {
 		field.Output = true
 	}
 
-	xGoogleImmutable, err := obj.JSONLookup("x-google-immutable")
-	if err == nil && xGoogleImmutable != nil {
+	if hasExtension("x-google-immutable") {
 		field.Immutable = true
 	}
 
-	return field
+	// Input only fields aren't returned by the API
+	if obj.Value.WriteOnly || hasExtension("x-google-input-only") {
+		field.IgnoreRead = true
+	}
+
+	if hasExtension("x-google-required") && !field.Output {
+		field.Required = true
+	}
+
+	if hasExtension("x-google-non-empty-default") && !field.Output {
+		field.DefaultFromApi = true
+	}
+
+	if hasExtension("x-google-unordered-list") && field.IsA("Array") {
+		field.IsSet = true
+	}
+
+	if obj.Value.Deprecated {
+		field.DeprecationMessage = fmt.Sprintf("`%s` is deprecated and will be removed in a future major release.", google.Underscore(field.Name))
+	}
+}
+
+// Returns the validation function for the minimum and maximum of a number,
+// with kind Int or Float. Int bounds that aren't integers are skipped, as
+// they can't be written as Go int literals.
+func rangeValidation(kind string, schema *openapi3.Schema) string {
+	var bounds []string
+	for _, bound := range []*float64{schema.Min, schema.Max} {
+		switch {
+		case bound == nil:
+			bounds = append(bounds, "")
+		case kind != "Int":
+			bounds = append(bounds, fmt.Sprintf("%v", *bound))
+		case *bound != math.Trunc(*bound) || *bound < math.MinInt64 || *bound >= math.MaxInt64:
+			log.Printf("Skipping the validation of the integer bound %v, which isn't an integer", *bound)
+			return ""
+		default:
+			bounds = append(bounds, fmt.Sprintf("%d", int64(*bound)))
+		}
+	}
+
+	min, max := bounds[0], bounds[1]
+	switch {
+	case min != "" && max != "":
+		return fmt.Sprintf("validation.%sBetween(%s, %s)", kind, min, max)
+	case min != "":
+		return fmt.Sprintf("validation.%sAtLeast(%s)", kind, min)
+	case max != "":
+		return fmt.Sprintf("validation.%sAtMost(%s)", kind, max)
+	}
+	return ""
 }
 
-func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
+func lengthValidation(schema *openapi3.Schema) string {
+	if schema.MaxLength != nil {
+		return fmt.Sprintf("validation.StringLenBetween(%d, %d)", schema.MinLength, *schema.MaxLength)
+	}
+	return ""
+}
+
+func buildProperties(schema *openapi3.Schema, lineage string, inList bool) []*api.Type {
 	properties := []*api.Type{}
-	for _, k := range slices.Sorted(maps.Keys(props)) {
-		prop := props[k]
-		propObj := writeObject(k, prop, propType(prop), false)
-		if slices.Contains(required, k) {
+	for _, k := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[k]
+		propObj := writeObject(k, lineage, prop, propType(prop), false, inList)
+		if slices.Contains(schema.Required, k) && !propObj.Output {
 			propObj.Required = true
 		}
 		properties = append(properties, &propObj)
 	}
+
+	// Fields listed as required by the alternatives of oneOf are exclusive,
+	// and exactly one of them must be set. Fields in lists and maps can't be
+	// referenced by path, so the constraint is left out there.
+	var oneOf []string
+	for _, alternative := range schema.OneOf {
+		for _, k := range alternative.Value.Required {
+			if _, ok := schema.Properties[k]; ok && !slices.Contains(oneOf, k) {
+				oneOf = append(oneOf, k)
+			}
+		}
+	}
+	if inList || len(oneOf) < 2 {
+		return properties
+	}
+	var paths []string
+	for _, k := range oneOf {
+		paths = append(paths, lineage+google.Underscore(k))
+	}
+	for _, p := range properties {
+		if slices.Contains(oneOf, p.Name) {
+			p.ExactlyOneOf = paths
+		}
+	}
 	return properties
 }
 
@@ -0,0 +1,147 @@
+package openapi_generate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testOpenApiDoc = `
+openapi: 3.0.3
+info:
+  title: Widgets API
+  version: v1
+paths: {}
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        name:
+          type: string
+          x-google-identifier: true
+        state:
+          type: string
+          readOnly: true
+          enum: [STATE_UNSPECIFIED, ACTIVE, DELETING]
+        sizeBytes:
+          type: string
+          format: int64
+        createTime:
+          type: string
+          format: date-time
+          readOnly: true
+        password:
+          type: string
+          format: password
+          writeOnly: true
+        replicas:
+          type: integer
+          minimum: 1
+          maximum: 10
+        nodeCount:
+          type: string
+          format: int64
+          maximum: 1000000
+        fractionalCount:
+          type: integer
+          minimum: 0.5
+        ratio:
+          type: number
+          minimum: 0
+        displayName:
+          type: string
+          maxLength: 63
+        legacyName:
+          type: string
+          deprecated: true
+        zones:
+          type: array
+          x-google-unordered-list: true
+          items:
+            type: string
+            enum: [ZONE_A, ZONE_B]
+        scaling:
+          type: object
+          oneOf:
+            - required: [nodeCount]
+            - required: [autoscaling]
+          properties:
+            nodeCount:
+              type: integer
+            autoscaling:
+              type: object
+              properties:
+                maxNodes:
+                  type: integer
+        backends:
+          type: object
+          additionalProperties:
+            type: object
+            properties:
+              weight:
+                type: integer
+              uri:
+                type: string
+                x-google-required: true
+`
+
+func TestBuildProperties(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(testOpenApiDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.Components.Schemas["Widget"].Value
+
+	properties := map[string]*api.Type{}
+	for _, p := range buildProperties(schema, "", false) {
+		properties[p.Name] = p
+	}
+
+	scaling := properties["scaling"]
+	if scaling == nil || len(scaling.Properties) != 2 {
+		t.Fatalf("expected scaling to have 2 properties, got %v", scaling)
+	}
+	backends := properties["backends"]
+	if backends == nil || backends.ValueType == nil || len(backends.ValueType.Properties) != 2 {
+		t.Fatalf("expected backends to have a value type with 2 properties, got %v", backends)
+	}
+
+	cases := []struct {
+		description string
+		got, want   any
+	}{
+		{"identifier output", properties["name"].Output, true},
+		{"readOnly output", properties["state"].Output, true},
+		{"enum type", properties["state"].Type, "Enum"},
+		{"enum values", properties["state"].EnumValues, []string{"ACTIVE", "DELETING"}},
+		{"int64 type", properties["sizeBytes"].Type, "Integer"},
+		{"date-time type", properties["createTime"].Type, "String"},
+		{"date-time description", properties["createTime"].Description, `A timestamp in RFC3339 UTC "Zulu" format, with nanosecond resolution and up to nine fractional digits. Examples: "2014-10-02T15:01:23Z" and "2014-10-02T15:01:23.045123456Z".`},
+		{"password sensitive", properties["password"].Sensitive, true},
+		{"writeOnly ignore read", properties["password"].IgnoreRead, true},
+		{"integer range", properties["replicas"].Validation.Function, "validation.IntBetween(1, 10)"},
+		{"integer bound", properties["nodeCount"].Validation.Function, "validation.IntAtMost(1000000)"},
+		{"non-integer bound", properties["fractionalCount"].Validation.Function, ""},
+		{"number minimum", properties["ratio"].Validation.Function, "validation.FloatAtLeast(0)"},
+		{"string length", properties["displayName"].Validation.Function, "validation.StringLenBetween(0, 63)"},
+		{"deprecated", properties["legacyName"].DeprecationMessage, "`legacy_name` is deprecated and will be removed in a future major release."},
+		{"unordered list", properties["zones"].IsSet, true},
+		{"enum items", properties["zones"].ItemType.EnumValues, []string{"ZONE_A", "ZONE_B"}},
+		{"oneOf", scaling.Properties[0].ExactlyOneOf, []string{"scaling.0.node_count", "scaling.0.autoscaling"}},
+		{"oneOf nested object", scaling.Properties[0].Name, "autoscaling"},
+		{"map type", backends.Type, "Map"},
+		{"map key name", backends.KeyName, "name"},
+		{"map value type", backends.ValueType.Type, "NestedObject"},
+		{"map value required", backends.ValueType.Properties[0].Required, true},
+	}
+	for _, tc := range cases {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("expected %s %v to be %v", tc.description, tc.got, tc.want)
+		}
+	}
+}
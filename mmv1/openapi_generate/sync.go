@@ -0,0 +1,352 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// The changes made to a resource YAML file when syncing it with its spec,
+// and the differences left for review.
+type SyncReport struct {
+	File string
+
+	// True if the file didn't exist and was generated from the spec
+	Created bool
+
+	// Paths of the properties added from the spec
+	Added []string
+
+	// Paths of the properties that aren't in the spec anymore. They are kept,
+	// as they may have been removed from the API or renamed.
+	Removed []string
+
+	// Paths of the properties whose placeholder description was replaced by
+	// the description from the spec
+	UpdatedDescriptions []string
+
+	// Paths of the properties whose description differs from the spec. They
+	// are kept, as they may have been customized.
+	CustomDescriptions []string
+}
+
+func (r SyncReport) String() string {
+	if r.Created {
+		return fmt.Sprintf("%s: created\n", r.File)
+	}
+	if len(r.Added)+len(r.Removed)+len(r.UpdatedDescriptions)+len(r.CustomDescriptions) == 0 {
+		return fmt.Sprintf("%s: up to date\n", r.File)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s:\n", r.File)
+	for _, section := range []struct {
+		title string
+		paths []string
+	}{
+		{"added", r.Added},
+		{"removed from the spec, kept", r.Removed},
+		{"description updated", r.UpdatedDescriptions},
+		{"description differs from the spec, kept", r.CustomDescriptions},
+	} {
+		for _, p := range section.paths {
+			fmt.Fprintf(&sb, "  %s: %s\n", section.title, p)
+		}
+	}
+	return sb.String()
+}
+
+// Sync updates the existing resource YAML files of the products described
+// by the OpenAPI files with the properties added to the spec, instead of
+// overwriting them. The files are patched in place, so that comments,
+// formatting and hand-set attributes are kept, and a report of the changes is
+// printed.
+func (parser Parser) Sync() {
+	for _, file := range parser.files() {
+		for _, report := range parser.SyncYaml(file) {
+			fmt.Print(report)
+		}
+	}
+}
+
+func (parser Parser) SyncYaml(filePath string) []SyncReport {
+	log.Printf("Reading from file path %s", filePath)
+
+	ctx := context.Background()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, _ := loader.LoadFromFile(filePath)
+	_ = doc.Validate(ctx)
+
+	header, err := os.ReadFile("openapi_generate/header.txt")
+	if err != nil {
+		log.Fatalf("error reading header %v", err)
+	}
+
+	productName := strings.Split(filepath.Base(filePath), "_")[0]
+	productPath := filepath.Join(parser.Output, productName)
+	if _, err := os.Stat(filepath.Join(productPath, "product.yaml")); err != nil {
+		buildProduct(filePath, parser.Output, doc, header)
+	}
+
+	// Disables line wrap for long strings
+	yamlv2.FutureLineWrap()
+
+	var reports []SyncReport
+	for _, pathArray := range findResources(doc) {
+		spec := buildResource(filePath, pathArray[0], pathArray[1], doc)
+		resourcePath := filepath.Join(productPath, fmt.Sprintf("%s.yaml", spec.Name))
+
+		if _, err := os.Stat(resourcePath); err != nil {
+			writeYamlFile(resourcePath, header, spec)
+			reports = append(reports, SyncReport{File: resourcePath, Created: true})
+			continue
+		}
+
+		report, err := syncResource(resourcePath, spec)
+		if err != nil {
+			log.Fatalf("error syncing %s: %v", resourcePath, err)
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].File < reports[j].File
+	})
+	return reports
+}
+
+// Syncs the properties of the resource YAML file at resourcePath with the
+// resource built from the spec.
+func syncResource(resourcePath string, spec api.Resource) (SyncReport, error) {
+	report := SyncReport{File: resourcePath}
+
+	existing := &api.Resource{}
+	if errs := api.Compile(resourcePath, existing, ""); len(errs) > 0 {
+		return report, errs
+	}
+
+	content, err := os.ReadFile(resourcePath)
+	if err != nil {
+		return report, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return report, err
+	}
+	if len(root.Content) == 0 {
+		return report, fmt.Errorf("the file is empty")
+	}
+
+	s := &yamlSync{lines: strings.Split(string(content), "\n"), report: &report}
+	if err := s.properties(existing.Properties, spec.Properties, mappingValue(root.Content[0], "properties"), ""); err != nil {
+		return report, err
+	}
+	if len(s.edits) == 0 {
+		return report, nil
+	}
+
+	return report, os.WriteFile(resourcePath, []byte(s.apply()), 0644)
+}
+
+// Replaces count lines of a file, starting at the 0-based line, by text.
+type lineEdit struct {
+	line  int
+	count int
+	text  []string
+}
+
+type yamlSync struct {
+	lines  []string
+	edits  []lineEdit
+	report *SyncReport
+}
+
+// Syncs the existing properties, read from seq, with the properties of the
+// spec. Properties are matched on their API name.
+func (s *yamlSync) properties(existing, spec []*api.Type, seq *yaml.Node, path string) error {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		log.Printf("Skipping the properties of %q, which aren't a list in %s", path, s.report.File)
+		return nil
+	}
+	if len(seq.Content) != len(existing) {
+		return fmt.Errorf("cannot match the properties of %q to their YAML", path)
+	}
+
+	var added []*api.Type
+	for _, specProp := range spec {
+		i := -1
+		for j, p := range existing {
+			if apiName(p) == specProp.Name {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			added = append(added, specProp)
+			s.report.Added = append(s.report.Added, joinPath(path, specProp.Name))
+			continue
+		}
+
+		prop, node := existing[i], seq.Content[i]
+		propPath := joinPath(path, prop.Name)
+		s.description(prop, specProp, node, propPath)
+
+		switch {
+		case len(specProp.Properties) > 0 && prop.IsA("NestedObject"):
+			if err := s.properties(prop.Properties, specProp.Properties, mappingValue(node, "properties"), propPath); err != nil {
+				return err
+			}
+		case specProp.ItemType != nil && len(specProp.ItemType.Properties) > 0 && prop.ItemType != nil && prop.ItemType.IsA("NestedObject"):
+			if err := s.properties(prop.ItemType.Properties, specProp.ItemType.Properties, mappingValue(mappingValue(node, "item_type"), "properties"), propPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, p := range existing {
+		if p.UrlParamOnly || p.ClientSide {
+			continue
+		}
+		found := false
+		for _, specProp := range spec {
+			found = found || apiName(p) == specProp.Name
+		}
+		if !found {
+			s.report.Removed = append(s.report.Removed, joinPath(path, p.Name))
+		}
+	}
+
+	if len(added) > 0 {
+		bytes, err := yamlv2.Marshal(added)
+		if err != nil {
+			return err
+		}
+		// Items are indented like the existing ones, after the last line of
+		// the sequence
+		dash := strings.Repeat(" ", seq.Content[0].Column-3)
+		last := s.lastLine(seq)
+		s.edits = append(s.edits, lineEdit{line: last + 1, text: indentLines(strings.TrimSuffix(string(bytes), "\n"), dash)})
+	}
+	return nil
+}
+
+// Replaces a placeholder description by the description from the spec, and
+// reports other descriptions that differ from it.
+func (s *yamlSync) description(prop, specProp *api.Type, node *yaml.Node, path string) {
+	if strings.TrimSpace(prop.Description) == strings.TrimSpace(specProp.Description) || isPlaceholder(specProp.Description) {
+		return
+	}
+
+	value := mappingValue(node, "description")
+	if !isPlaceholder(prop.Description) || value == nil || value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		s.report.CustomDescriptions = append(s.report.CustomDescriptions, path)
+		return
+	}
+
+	bytes, err := yamlv2.Marshal(map[string]string{"description": specProp.Description})
+	if err != nil {
+		s.report.CustomDescriptions = append(s.report.CustomDescriptions, path)
+		return
+	}
+	indent := strings.Repeat(" ", node.Column-1)
+	s.edits = append(s.edits, lineEdit{line: value.Line - 1, count: 1, text: indentLines(strings.TrimSuffix(string(bytes), "\n"), indent)})
+	s.report.UpdatedDescriptions = append(s.report.UpdatedDescriptions, path)
+}
+
+// Returns the 0-based index of the last line of a block sequence, skipping
+// blank lines and comments that follow it.
+func (s *yamlSync) lastLine(seq *yaml.Node) int {
+	lastItem := seq.Content[len(seq.Content)-1]
+	dash := seq.Content[0].Column - 3
+
+	last := lastItem.Line - 1
+	for i := lastItem.Line; i < len(s.lines); i++ {
+		trimmed := strings.TrimSpace(s.lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if len(s.lines[i])-len(strings.TrimLeft(s.lines[i], " ")) <= dash {
+			break
+		}
+		last = i
+	}
+	return last
+}
+
+// Applies the edits from the end of the file, so that the lines of earlier
+// edits don't move.
+func (s *yamlSync) apply() string {
+	sort.SliceStable(s.edits, func(i, j int) bool {
+		return s.edits[i].line > s.edits[j].line
+	})
+	lines := s.lines
+	for _, e := range s.edits {
+		lines = append(lines[:e.line], append(e.text, lines[e.line+e.count:]...)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func apiName(p *api.Type) string {
+	if p.ApiName != "" {
+		return p.ApiName
+	}
+	return p.Name
+}
+
+// Descriptions written by the generator when the spec has none
+func isPlaceholder(description string) bool {
+	d := strings.TrimSpace(description)
+	return d == "" || d == "No description" || d == "Description"
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func indentLines(text, indent string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			lines = append(lines, line)
+			continue
+		}
+		lines = append(lines, indent+line)
+	}
+	return lines
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,129 @@
+package openapi_generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestSyncResource(t *testing.T) {
+	t.Parallel()
+
+	content := `# Copyright header
+---
+name: 'Widget'
+base_url: 'projects/{{project}}/widgets'
+properties:
+  # The display name is set by users
+  - name: 'displayName'
+    type: String
+    description: |
+      The display name of the widget.
+    diff_suppress_func: 'tpgresource.CaseDiffSuppress'
+  - name: 'size'
+    type: Integer
+    description: 'No description'
+  - name: 'networkConfig'
+    type: NestedObject
+    description: Network configuration.
+    properties:
+      - name: 'subnet'
+        type: String
+        description: The subnet.
+        custom_expand: 'templates/terraform/custom_expand/subnet.go.tmpl'
+
+  - name: 'legacyField'
+    type: String
+    description: A field removed from the API.
+# Trailing comment
+`
+	expected := `# Copyright header
+---
+name: 'Widget'
+base_url: 'projects/{{project}}/widgets'
+properties:
+  # The display name is set by users
+  - name: 'displayName'
+    type: String
+    description: |
+      The display name of the widget.
+    diff_suppress_func: 'tpgresource.CaseDiffSuppress'
+  - name: 'size'
+    type: Integer
+    description: The size of the widget.
+  - name: 'networkConfig'
+    type: NestedObject
+    description: Network configuration.
+    properties:
+      - name: 'subnet'
+        type: String
+        description: The subnet.
+        custom_expand: 'templates/terraform/custom_expand/subnet.go.tmpl'
+      - name: network
+        type: String
+        description: The network.
+
+  - name: 'legacyField'
+    type: String
+    description: A field removed from the API.
+  - name: state
+    type: String
+    description: The state of the widget.
+    output: true
+# Trailing comment
+`
+
+	spec := api.Resource{
+		Name: "Widget",
+		Properties: []*api.Type{
+			{Name: "displayName", Type: "String", Description: "Display name."},
+			{Name: "size", Type: "Integer", Description: "The size of the widget."},
+			{Name: "networkConfig", Type: "NestedObject", Description: "Network configuration.", Properties: []*api.Type{
+				{Name: "subnet", Type: "String", Description: "The subnet."},
+				{Name: "network", Type: "String", Description: "The network."},
+			}},
+			{Name: "state", Type: "String", Description: "The state of the widget.", Output: true},
+		},
+	}
+
+	resourcePath := filepath.Join(t.TempDir(), "Widget.yaml")
+	if err := os.WriteFile(resourcePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := syncResource(resourcePath, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(resourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != expected {
+		t.Errorf("expected synced YAML\n%s\nto be\n%s", got, expected)
+	}
+
+	want := SyncReport{
+		File:                resourcePath,
+		Added:               []string{"networkConfig.network", "state"},
+		Removed:             []string{"legacyField"},
+		UpdatedDescriptions: []string{"size"},
+		CustomDescriptions:  []string{"displayName"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("expected report %+v to be %+v", report, want)
+	}
+
+	// Syncing again finds no changes
+	report, err = syncResource(resourcePath, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := report.Added, []string(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected properties %v to be added again, got %v", want, got)
+	}
+}
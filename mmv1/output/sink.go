@@ -0,0 +1,318 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Receives the files generated by a provider. Paths are the same paths the
+// providers would write to on disk, including the output folder.
+type Sink interface {
+	// Writes a file, replacing any previous content.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+
+	// Reads a file previously written to the sink. Only FileSystemSink also
+	// reads files left in the output folder by earlier runs.
+	ReadFile(name string) ([]byte, error)
+
+	// Returns information about a file, or an error wrapping fs.ErrNotExist
+	// if it doesn't exist.
+	Stat(name string) (fs.FileInfo, error)
+
+	// Creates a directory and any missing parents.
+	MkdirAll(path string, perm fs.FileMode) error
+
+	// Flushes the output once generation is done.
+	Close() error
+}
+
+// Writes files straight to the filesystem.
+type FileSystemSink struct{}
+
+func (FileSystemSink) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (FileSystemSink) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (FileSystemSink) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (FileSystemSink) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (FileSystemSink) Close() error {
+	return nil
+}
+
+// Keeps generated files in memory. Safe for concurrent use, as products are
+// generated in parallel.
+type MemorySink struct {
+	files map[string]*memoryFile
+	mu    sync.RWMutex
+}
+
+type memoryFile struct {
+	path    string
+	name    string
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{files: make(map[string]*memoryFile)}
+}
+
+func (s *MemorySink) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[filepath.Clean(name)] = &memoryFile{
+		path:    filepath.Clean(name),
+		name:    filepath.Base(name),
+		data:    append([]byte(nil), data...),
+		mode:    perm,
+		modTime: time.Now(),
+	}
+	return nil
+}
+
+func (s *MemorySink) ReadFile(name string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+func (s *MemorySink) Stat(name string) (fs.FileInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return f, nil
+}
+
+// Directories only exist implicitly through the files in them.
+func (s *MemorySink) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+func (s *MemorySink) Close() error {
+	return nil
+}
+
+// Returns the paths of all files written, sorted.
+func (s *MemorySink) Paths() []string {
+	var paths []string
+	for _, f := range s.sortedFiles() {
+		paths = append(paths, f.path)
+	}
+	return paths
+}
+
+// Writes all files below root to the directory dir, keeping their paths
+// relative to root.
+func (s *MemorySink) WriteTo(root, dir string) error {
+	for _, f := range s.sortedFiles() {
+		rel, ok := relativePath(root, f.path)
+		if !ok {
+			continue
+		}
+		target := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, f.data, f.mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemorySink) sortedFiles() []*memoryFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files := make([]*memoryFile, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files
+}
+
+func (f *memoryFile) Name() string       { return f.name }
+func (f *memoryFile) Size() int64        { return int64(len(f.data)) }
+func (f *memoryFile) Mode() fs.FileMode  { return f.mode }
+func (f *memoryFile) ModTime() time.Time { return f.modTime }
+func (f *memoryFile) IsDir() bool        { return false }
+func (f *memoryFile) Sys() any           { return nil }
+
+// The supported archive formats for ArchiveSink.
+const (
+	ArchiveTar = "tar"
+	ArchiveZip = "zip"
+)
+
+// Collects generated files in memory and writes them to a tar or zip
+// archive on Close. Files are stored relative to the output folder.
+type ArchiveSink struct {
+	*MemorySink
+
+	// The output folder the providers generate into. It doesn't need to
+	// exist, as nothing is written to it.
+	root string
+
+	archivePath string
+	format      string
+}
+
+func NewArchiveSink(archivePath, root, format string) (*ArchiveSink, error) {
+	if format != ArchiveTar && format != ArchiveZip {
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+	return &ArchiveSink{
+		MemorySink:  NewMemorySink(),
+		root:        root,
+		archivePath: archivePath,
+		format:      format,
+	}, nil
+}
+
+func (s *ArchiveSink) Close() error {
+	out, err := os.Create(s.archivePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if s.format == ArchiveZip {
+		err = s.writeZip(out)
+	} else {
+		err = s.writeTar(out)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing archive %s: %w", s.archivePath, err)
+	}
+	return out.Close()
+}
+
+func (s *ArchiveSink) writeTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, f := range s.sortedFiles() {
+		rel, ok := relativePath(s.root, f.path)
+		if !ok {
+			continue
+		}
+		header := &tar.Header{
+			Name:    filepath.ToSlash(rel),
+			Mode:    int64(f.mode.Perm()),
+			Size:    int64(len(f.data)),
+			ModTime: f.modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func (s *ArchiveSink) writeZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, f := range s.sortedFiles() {
+		rel, ok := relativePath(s.root, f.path)
+		if !ok {
+			continue
+		}
+		header := &zip.FileHeader{
+			Name:     filepath.ToSlash(rel),
+			Method:   zip.Deflate,
+			Modified: f.modTime,
+		}
+		header.SetMode(f.mode)
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Returns the path of name relative to root, or false if name isn't below
+// root.
+func relativePath(root, name string) (string, bool) {
+	rel, err := filepath.Rel(root, name)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// Copies all files below the directory src to dst in sink.
+func CopyDirectory(sink Sink, src, dst string) error {
+	return filepath.WalkDir(src, func(path string, di fs.DirEntry, err error) error {
+		if err != nil || di.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		info, err := di.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+		if err := sink.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		return sink.WriteFile(target, data, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,201 @@
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMemorySink(t *testing.T) {
+	t.Parallel()
+
+	sink := NewMemorySink()
+	if err := sink.WriteFile("out/google/resource.go", []byte("package google\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.WriteFile("out/scripts/run.sh", []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := sink.ReadFile("out/google/../google/resource.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "package google\n"; got != want {
+		t.Errorf("expected content %q to be %q", got, want)
+	}
+
+	info, err := sink.Stat("out/scripts/run.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.Mode(), fs.FileMode(0755); got != want {
+		t.Errorf("expected mode %v to be %v", got, want)
+	}
+
+	if _, err := sink.Stat("out/missing.go"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a not exist error for a missing file, got %v", err)
+	}
+
+	if got, want := sink.Paths(), []string{"out/google/resource.go", "out/scripts/run.sh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected paths %v to be %v", got, want)
+	}
+
+	dir := t.TempDir()
+	if err := sink.WriteTo("out", dir); err != nil {
+		t.Fatal(err)
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "scripts/run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "#!/bin/sh\n"; got != want {
+		t.Errorf("expected content %q to be %q", got, want)
+	}
+}
+
+func TestArchiveSink(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		format      string
+		read        func(t *testing.T, archivePath string) map[string]string
+	}{
+		{
+			description: "tar",
+			format:      ArchiveTar,
+			read:        readTestTar,
+		},
+		{
+			description: "zip",
+			format:      ArchiveZip,
+			read:        readTestZip,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := filepath.Join(t.TempDir(), "terraform-provider-google."+tc.format)
+			sink, err := NewArchiveSink(archivePath, "terraform-provider-google", tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writes := map[string]string{
+				"terraform-provider-google/go.mod":                  "module test\n",
+				"terraform-provider-google/google/provider.go":      "package google\n",
+				"terraform-provider-google-beta/google/provider.go": "outside of the output folder",
+			}
+			for name, content := range writes {
+				if err := sink.WriteFile(name, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			expected := map[string]string{
+				"go.mod":             "module test\n",
+				"google/provider.go": "package google\n",
+			}
+			if got := tc.read(t, archivePath); !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected archive contents %v to be %v", got, expected)
+			}
+		})
+	}
+}
+
+func TestArchiveSinkUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewArchiveSink("out.rar", "out", "rar"); err == nil {
+		t.Errorf("expected an error for an unsupported format")
+	}
+}
+
+func TestCopyDirectory(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "go.mod"), "module test\n")
+	writeTestFile(t, filepath.Join(src, "pkg/util.go"), "package pkg\n")
+
+	sink := NewMemorySink()
+	if err := CopyDirectory(sink, src, "out"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sink.Paths(), []string{"out/go.mod", "out/pkg/util.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected paths %v to be %v", got, want)
+	}
+}
+
+func readTestTar(t *testing.T, archivePath string) map[string]string {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	files := make(map[string]string)
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func readTestZip(t *testing.T, archivePath string) map[string]string {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
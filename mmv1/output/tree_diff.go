@@ -0,0 +1,175 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// The differences between an existing output folder and the files rendered
+// for it. Paths are relative to the output folder.
+type TreeDiff struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+
+	root     string
+	rendered *MemorySink
+}
+
+// Compares the files rendered below root in a sink with the files at the same
+// paths on disk.
+//
+// A rendering often only covers part of the existing output, so a file that
+// only exists on disk is reported as removed only if its directory was
+// rendered to and isGenerated returns true for it.
+func DiffOutput(root string, rendered *MemorySink, isGenerated func(path string, content []byte) bool) (*TreeDiff, error) {
+	d := &TreeDiff{root: root, rendered: rendered}
+
+	renderedFiles := make(map[string]bool)
+	renderedDirs := make(map[string]bool)
+	for _, f := range rendered.sortedFiles() {
+		rel, ok := relativePath(root, f.path)
+		if !ok {
+			continue
+		}
+		renderedFiles[rel] = true
+		renderedDirs[filepath.Dir(rel)] = true
+
+		oldContent, err := os.ReadFile(filepath.Join(root, rel))
+		if errors.Is(err, fs.ErrNotExist) {
+			d.Added = append(d.Added, rel)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(oldContent, f.data) {
+			d.Modified = append(d.Modified, rel)
+		}
+	}
+
+	for dir := range renderedDirs {
+		entries, err := os.ReadDir(filepath.Join(root, dir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			rel := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || renderedFiles[rel] {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(root, rel))
+			if err != nil {
+				return nil, err
+			}
+			if isGenerated(rel, content) {
+				d.Removed = append(d.Removed, rel)
+			}
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Modified)
+	return d, nil
+}
+
+// Returns true if the rendered files don't differ from the output.
+func (d *TreeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Writes a summary of the added, removed and modified files, followed by a
+// unified diff of each file in the same order.
+func (d *TreeDiff) Write(w io.Writer) error {
+	sections := []struct {
+		title string
+		files []string
+	}{
+		{"Added", d.Added},
+		{"Removed", d.Removed},
+		{"Modified", d.Modified},
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(w, "%s files (%d):\n", section.title, len(section.files))
+		for _, f := range section.files {
+			fmt.Fprintf(w, "  %s\n", f)
+		}
+	}
+
+	for _, section := range sections {
+		for _, f := range section.files {
+			if err := d.writeFileDiff(w, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *TreeDiff) writeFileDiff(w io.Writer, rel string) error {
+	oldName, newName := filepath.ToSlash(filepath.Join("a", rel)), filepath.ToSlash(filepath.Join("b", rel))
+
+	oldContent, err := os.ReadFile(filepath.Join(d.root, rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		oldName = "/dev/null"
+	} else if err != nil {
+		return err
+	}
+	newContent, err := d.rendered.ReadFile(filepath.Join(d.root, rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		newName = "/dev/null"
+	} else if err != nil {
+		return err
+	}
+
+	if bytes.IndexByte(oldContent, 0) != -1 || bytes.IndexByte(newContent, 0) != -1 {
+		_, err := fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+		return err
+	}
+
+	return difflib.WriteUnifiedDiff(w, difflib.UnifiedDiff{
+		A:        diffLines(oldContent),
+		B:        diffLines(newContent),
+		FromFile: oldName,
+		ToFile:   newName,
+		Context:  3,
+	})
+}
+
+// Splits content into lines that keep their line endings, as expected by
+// difflib. A missing newline at the end of the content is added.
+func diffLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffOutput(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	existing := map[string]string{
+		"services/pubsub/unchanged.go":   "unchanged\n",
+		"services/pubsub/modified.go":    "before\n",
+		"services/pubsub/removed.go":     "// generated\n",
+		"services/pubsub/handwritten.go": "// handwritten\n",
+		"services/compute/untouched.go":  "// generated\n",
+	}
+	for rel, content := range existing {
+		writeTestFile(t, filepath.Join(root, rel), content)
+	}
+
+	rendered := NewMemorySink()
+	renders := map[string]string{
+		"services/pubsub/unchanged.go": "unchanged\n",
+		"services/pubsub/modified.go":  "after\n",
+		"services/pubsub/added.go":     "added\n",
+	}
+	for rel, content := range renders {
+		if err := rendered.WriteFile(filepath.Join(root, rel), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Files outside of the output folder aren't compared
+	if err := rendered.WriteFile(filepath.Join(filepath.Dir(root), "other", "outside.go"), []byte("outside\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffOutput(root, rendered, func(path string, content []byte) bool {
+		return bytes.Contains(content, []byte("generated"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := diff.Added, []string{filepath.Join("services", "pubsub", "added.go")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected added files %v to be %v", got, want)
+	}
+	if got, want := diff.Removed, []string{filepath.Join("services", "pubsub", "removed.go")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected removed files %v to be %v", got, want)
+	}
+	if got, want := diff.Modified, []string{filepath.Join("services", "pubsub", "modified.go")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected modified files %v to be %v", got, want)
+	}
+
+	var out bytes.Buffer
+	if err := diff.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"--- /dev/null\n+++ b/services/pubsub/added.go\n",
+		"--- a/services/pubsub/removed.go\n+++ /dev/null\n",
+		"-before\n+after\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected diff to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The file within the output folder that the generation cache is stored in.
+const GenerationCacheFile = ".mmv1-generation-cache.json"
+
+// Records the inputs and outputs of each generated resource, so that a later
+// run into the same output folder can skip resources whose YAML and templates
+// haven't changed since.
+type GenerationCache struct {
+	// Identifies the generator binary and the options it was run with. All
+	// entries are discarded when this changes.
+	Generator string `json:"generator"`
+
+	// Entries keyed by the product and resource they were generated from.
+	Entries map[string]*GenerationCacheEntry `json:"entries"`
+
+	outputFolder string
+
+	// Entries generated during this run, whose output hashes are recorded
+	// on Save once the files are final.
+	generated map[string]bool
+
+	mu sync.Mutex
+}
+
+type GenerationCacheEntry struct {
+	// Hashes of the YAML files and templates read while generating the
+	// entry, keyed by their path relative to the magic-modules directory.
+	Inputs map[string]string `json:"inputs"`
+
+	// Hashes of the files generated for the entry, keyed by their path
+	// relative to the output folder.
+	Outputs map[string]string `json:"outputs"`
+}
+
+// The cache used by this run, if incremental generation was requested.
+var generationCache *GenerationCache
+
+// Enables incremental generation into outputFolder, loading the cache left
+// there by a previous run. settings should describe every option that
+// affects the generated files, such as the provider and version.
+func EnableGenerationCache(outputFolder, settings string) {
+	generator, err := generatorFingerprint(settings)
+	if err != nil {
+		log.Printf("Cannot identify the generator, incremental generation is disabled: %v", err)
+		return
+	}
+
+	generationCache = LoadGenerationCache(outputFolder, generator)
+}
+
+// Writes the cache of this run, if any, to the output folder. This must be
+// called after FixImports so that the recorded hashes match the final files.
+func SaveGenerationCache() {
+	if generationCache == nil {
+		return
+	}
+
+	if err := generationCache.Save(); err != nil {
+		log.Printf("Cannot save the generation cache: %v", err)
+	}
+}
+
+// Reads the cache in outputFolder. A missing or unreadable cache, or one
+// written by a different generator, results in an empty cache.
+func LoadGenerationCache(outputFolder, generator string) *GenerationCache {
+	c := &GenerationCache{
+		Generator:    generator,
+		Entries:      make(map[string]*GenerationCacheEntry),
+		outputFolder: outputFolder,
+		generated:    make(map[string]bool),
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputFolder, GenerationCacheFile))
+	if err != nil {
+		return c
+	}
+
+	var previous GenerationCache
+	if err := json.Unmarshal(content, &previous); err != nil {
+		log.Printf("Ignoring unreadable generation cache: %v", err)
+		return c
+	}
+	if previous.Generator != generator {
+		log.Printf("Generator or options changed since the last run, regenerating everything")
+		return c
+	}
+	if previous.Entries != nil {
+		c.Entries = previous.Entries
+	}
+	return c
+}
+
+// Returns true if the entry at key was generated from exactly yamlFiles and
+// none of its inputs or outputs changed since.
+func (c *GenerationCache) Fresh(key string, yamlFiles []string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.Entries[key]
+	c.mu.Unlock()
+	if !ok || len(entry.Outputs) == 0 {
+		return false
+	}
+
+	// Adding or removing an override changes the YAML files without
+	// changing any of the previous ones
+	for _, f := range yamlFiles {
+		if _, ok := entry.Inputs[f]; !ok {
+			return false
+		}
+	}
+
+	for f, hash := range entry.Inputs {
+		if fileHash(f) != hash {
+			return false
+		}
+	}
+	for f, hash := range entry.Outputs {
+		if fileHash(filepath.Join(c.outputFolder, f)) != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// Starts recording a new entry at key, replacing any previous one.
+func (c *GenerationCache) Start(key string) *GenerationCacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	entry := &GenerationCacheEntry{
+		Inputs:  make(map[string]string),
+		Outputs: make(map[string]string),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[key] = entry
+	c.generated[key] = true
+	return entry
+}
+
+// Returns the entry started at key during this run, if any.
+func (c *GenerationCache) Entry(key string) *GenerationCacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.generated[key] {
+		return nil
+	}
+	return c.Entries[key]
+}
+
+// Records files read while generating the entry.
+func (e *GenerationCacheEntry) AddInputs(files ...string) {
+	if e == nil {
+		return
+	}
+
+	for _, f := range files {
+		if _, ok := e.Inputs[f]; !ok {
+			e.Inputs[f] = fileHash(f)
+		}
+	}
+}
+
+// Records a file generated for the entry. Its hash is computed on Save.
+func (e *GenerationCacheEntry) AddOutput(outputFolder, filePath string) {
+	if e == nil {
+		return
+	}
+
+	rel, err := filepath.Rel(outputFolder, filePath)
+	if err != nil {
+		rel = filePath
+	}
+	e.Outputs[rel] = ""
+}
+
+// Hashes the files generated during this run and writes the cache to the
+// output folder.
+func (c *GenerationCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.generated {
+		entry := c.Entries[key]
+		for f := range entry.Outputs {
+			entry.Outputs[f] = fileHash(filepath.Join(c.outputFolder, f))
+		}
+	}
+
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.outputFolder, GenerationCacheFile), content, 0644)
+}
+
+// Returns the templates referenced by the YAML of obj, such as custom code,
+// custom expanders and flatteners, and example configs. Fields that aren't
+// read from YAML are skipped, as are the struct fields named in skip.
+func customTemplatePaths(obj any, skip ...string) []string {
+	var paths []string
+	seen := make(map[uintptr]bool)
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() || seen[v.Pointer()] {
+				return
+			}
+			seen[v.Pointer()] = true
+			walk(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				if !field.IsExported() || field.Tag.Get("yaml") == "-" {
+					continue
+				}
+				if slices.Contains(skip, field.Name) {
+					continue
+				}
+				walk(v.Field(i))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				walk(iter.Value())
+			}
+		case reflect.String:
+			if s := v.String(); strings.HasSuffix(s, ".tmpl") {
+				if _, err := os.Stat(s); err == nil {
+					paths = append(paths, s)
+				}
+			}
+		}
+	}
+
+	walk(reflect.ValueOf(obj))
+	return paths
+}
+
+// Identifies the running generator binary together with settings, so that
+// rebuilding the generator or changing its options invalidates the cache.
+func generatorFingerprint(settings string) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	hash := fileHash(executable)
+	if hash == "" {
+		return "", fmt.Errorf("cannot read %s", executable)
+	}
+	return fmt.Sprintf("%s %s", hash, settings), nil
+}
+
+// Returns the hex encoded SHA-256 hash of the file at path, or "" if it
+// can't be read.
+func fileHash(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Cannot hash %s: %v", path, err)
+		}
+		return ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		log.Printf("Cannot hash %s: %v", path, err)
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
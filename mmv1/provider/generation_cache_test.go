@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerationCacheFresh(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yamlFiles   []string
+		change      func(t *testing.T, inputFolder, outputFolder string)
+		generator   string
+		expected    bool
+	}{
+		{
+			description: "unchanged",
+			expected:    true,
+		},
+		{
+			description: "changed yaml",
+			change: func(t *testing.T, inputFolder, outputFolder string) {
+				writeTestFile(t, filepath.Join(inputFolder, "Resource.yaml"), "name: 'Changed'")
+			},
+		},
+		{
+			description: "changed template",
+			change: func(t *testing.T, inputFolder, outputFolder string) {
+				writeTestFile(t, filepath.Join(inputFolder, "resource.go.tmpl"), "changed")
+			},
+		},
+		{
+			description: "added override",
+			yamlFiles:   []string{"override/Resource.yaml"},
+		},
+		{
+			description: "modified output",
+			change: func(t *testing.T, inputFolder, outputFolder string) {
+				writeTestFile(t, filepath.Join(outputFolder, "resource.go"), "modified")
+			},
+		},
+		{
+			description: "deleted output",
+			change: func(t *testing.T, inputFolder, outputFolder string) {
+				if err := os.Remove(filepath.Join(outputFolder, "resource.go")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			description: "different generator",
+			generator:   "other",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			inputFolder := t.TempDir()
+			outputFolder := t.TempDir()
+			yamlFile := filepath.Join(inputFolder, "Resource.yaml")
+			templateFile := filepath.Join(inputFolder, "resource.go.tmpl")
+			outputFile := filepath.Join(outputFolder, "resource.go")
+			writeTestFile(t, yamlFile, "name: 'Resource'")
+			writeTestFile(t, templateFile, "template")
+			writeTestFile(t, outputFile, "output")
+
+			cache := LoadGenerationCache(outputFolder, "generator")
+			entry := cache.Start("Product/Resource")
+			entry.AddInputs(yamlFile, templateFile)
+			entry.AddOutput(outputFolder, outputFile)
+			if err := cache.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.change != nil {
+				tc.change(t, inputFolder, outputFolder)
+			}
+			generator := "generator"
+			if tc.generator != "" {
+				generator = tc.generator
+			}
+
+			cache = LoadGenerationCache(outputFolder, generator)
+			yamlFiles := append([]string{yamlFile}, tc.yamlFiles...)
+			if got, want := cache.Fresh("Product/Resource", yamlFiles), tc.expected; got != want {
+				t.Errorf("expected fresh %t to be %t", got, want)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
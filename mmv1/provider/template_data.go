@@ -28,6 +28,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 	"github.com/golang/glog"
 )
 
@@ -38,6 +39,13 @@ type TemplateData struct {
 	TerraformResourceDirectory string
 	TerraformProviderModule    string
 
+	// Receives the generated files
+	Sink output.Sink
+
+	// Records the templates and files of generated resources when
+	// generating incrementally. Nil otherwise.
+	cacheEntry *GenerationCacheEntry
+
 	// TODO rewrite: is this needed?
 	//     # Information about the local environment
 	//     # (which formatters are enabled, start-time)
@@ -51,8 +59,8 @@ var PRIVATE_VERSION = "private"
 
 var goimportFiles sync.Map
 
-func NewTemplateData(outputFolder string, versionName string) *TemplateData {
-	td := TemplateData{OutputFolder: outputFolder, VersionName: versionName}
+func NewTemplateData(outputFolder string, versionName string, sink output.Sink) *TemplateData {
+	td := TemplateData{OutputFolder: outputFolder, VersionName: versionName, Sink: sink}
 
 	if versionName == GA_VERSION {
 		td.TerraformResourceDirectory = "google"
@@ -77,10 +85,12 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 		"templates/terraform/expand_resource_ref.tmpl",
 		"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl",
 		"templates/terraform/flatten_property_method.go.tmpl",
+		"templates/terraform/flatten_item_method.go.tmpl",
 		"templates/terraform/expand_property_method.go.tmpl",
 		"templates/terraform/update_mask.go.tmpl",
 		"templates/terraform/nested_query.go.tmpl",
 		"templates/terraform/unordered_list_customize_diff.go.tmpl",
+		"templates/terraform/state_upgrade.go.tmpl",
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
@@ -148,6 +158,129 @@ func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource)
 	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
 }
 
+func (td *TemplateData) GenerateDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateDatasourceTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/examples/base_configs/datasource_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := TestInput{
+		Res:        resource,
+		ImportPath: td.ImportPath(),
+	}
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource_list.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource_list.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/examples/base_configs/datasource_list_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := TestInput{
+		Res:        resource,
+		ImportPath: td.ImportPath(),
+	}
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/schema_property.go.tmpl",
+		"templates/terraform/schema_subresource.go.tmpl",
+		"templates/terraform/flatten_property_method.go.tmpl",
+		"templates/terraform/flatten_item_method.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateActionFile(filePath string, action api.Action) {
+	templatePath := "templates/terraform/action.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/schema_property.go.tmpl",
+		"templates/terraform/schema_subresource.go.tmpl",
+		"templates/terraform/expand_resource_ref.tmpl",
+		"templates/terraform/expand_property_method.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, action, true, templates...)
+}
+
+func (td *TemplateData) GenerateActionDocumentationFile(filePath string, action api.Action) {
+	templatePath := "templates/terraform/action.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, action, false, templates...)
+}
+
+func (td *TemplateData) GenerateFrameworkResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/framework_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/framework_schema_property.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
 func (td *TemplateData) GenerateIamPolicyFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/iam_policy.go.tmpl"
 	templates := []string{
@@ -235,6 +368,7 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 	if err != nil {
 		glog.Exit(fmt.Sprintf("error parsing %s for filepath %s ", templateFileName, filePath), err)
 	}
+	td.cacheEntry.AddInputs(templates...)
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, input); err != nil {
@@ -258,10 +392,11 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 		}
 	}
 
-	err = os.WriteFile(filePath, sourceByte, 0644)
+	err = td.Sink.WriteFile(filePath, sourceByte, 0644)
 	if err != nil {
 		glog.Exit(err)
 	}
+	td.cacheEntry.AddOutput(td.OutputFolder, filePath)
 }
 
 func (td *TemplateData) ImportPath() string {
@@ -273,9 +408,79 @@ func (td *TemplateData) ImportPath() string {
 	return "github.com/hashicorp/terraform-provider-google-beta/google-beta"
 }
 
-func FixImports(outputPath string, dumpDiffs bool) {
+// Runs goimports on the generated go files. Files kept in memory by sink are
+// written to a temporary directory for goimports, and read back from there.
+func FixImports(sink output.Sink, outputPath string, dumpDiffs bool) {
 	log.Printf("Fixing go import paths")
 
+	var memory *output.MemorySink
+	switch s := sink.(type) {
+	case *output.MemorySink:
+		memory = s
+	case *output.ArchiveSink:
+		memory = s.MemorySink
+	}
+
+	var err error
+	if memory != nil {
+		err = fixImportsInMemory(memory, outputPath, dumpDiffs)
+	} else {
+		err = runGoimports(outputPath, outputPath, dumpDiffs)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Runs goimports on the generated go files kept in memory, in a temporary
+// directory that's removed afterwards.
+func fixImportsInMemory(memory *output.MemorySink, outputPath string, dumpDiffs bool) error {
+	tempDir, err := os.MkdirTemp("", "mmv1-goimports")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	if err := memory.WriteTo(outputPath, tempDir); err != nil {
+		return err
+	}
+
+	if err := runGoimports(outputPath, tempDir, dumpDiffs); err != nil {
+		return err
+	}
+
+	// Besides formatting the go files, goimports may update files such as
+	// go.sum, so every file is read back
+	for _, name := range memory.Paths() {
+		rel, err := filepath.Rel(outputPath, name)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(tempDir, rel))
+		if err != nil {
+			return err
+		}
+		previous, err := memory.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(content, previous) {
+			continue
+		}
+		info, err := memory.Stat(name)
+		if err != nil {
+			return err
+		}
+		if err := memory.WriteFile(name, content, info.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Runs goimports in dir on the generated go files, whose paths are relative
+// to outputPath.
+func runGoimports(outputPath, dir string, dumpDiffs bool) error {
+
 	baseArgs := []string{"-w"}
 	if dumpDiffs {
 		baseArgs = []string{"-d", "-w"}
@@ -283,21 +488,24 @@ func FixImports(outputPath string, dumpDiffs bool) {
 
 	// -w and -d are mutually exclusive; if dumpDiffs is requested we need to run twice.
 	for _, base := range baseArgs {
-		hasFiles := false
+		var err error
 		args := []string{base}
 		goimportFiles.Range(func(filePath, _ any) bool {
-			p, err := filepath.Rel(outputPath, filePath.(string))
+			var p string
+			p, err = filepath.Rel(outputPath, filePath.(string))
 			if err != nil {
-				log.Fatal(err)
+				return false
 			}
 			args = append(args, p)
-			hasFiles = true
 			return true
 		})
+		if err != nil {
+			return err
+		}
 
-		if hasFiles {
+		if len(args) > 1 {
 			cmd := exec.Command("goimports", args...)
-			cmd.Dir = outputPath
+			cmd.Dir = dir
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
 			if err := cmd.Run(); err != nil {
@@ -305,10 +513,11 @@ func FixImports(outputPath string, dumpDiffs bool) {
 				if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
 					glog.Error(string(exitErr.Stderr))
 				}
-				log.Fatal(err)
+				return err
 			}
 		}
 	}
+	return nil
 }
 
 type TestInput struct {
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
+)
+
+func TestGenerateFileToMemorySink(t *testing.T) {
+	t.Parallel()
+
+	templatePath := filepath.Join(t.TempDir(), "resource.go.tmpl")
+	writeTestFile(t, templatePath, "package {{ .Name }}\nvar x   =  1\n")
+
+	sink := output.NewMemorySink()
+	outputFolder := t.TempDir()
+	td := NewTemplateData(outputFolder, GA_VERSION, sink)
+	filePath := filepath.Join(outputFolder, "third_party/terraform/resource.go")
+	td.GenerateFile(filePath, "third_party/terraform/resource.go.tmpl", struct{ Name string }{"google"}, true, templatePath)
+
+	content, err := sink.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "package google\n\nvar x = 1\n"; got != want {
+		t.Errorf("expected content %q to be %q", got, want)
+	}
+	if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected %s not to be written to disk", filePath)
+	}
+}
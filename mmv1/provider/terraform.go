@@ -32,6 +32,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 )
 
 type Terraform struct {
@@ -41,6 +42,10 @@ type Terraform struct {
 
 	ResourcesForVersion []map[string]string
 
+	// The functions returning the generated actions, such as
+	// "compute.NewComputeInstanceRestartAction"
+	ActionsForVersion []string
+
 	TargetVersionName string
 
 	Version product.Version
@@ -48,9 +53,12 @@ type Terraform struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Receives the generated files
+	Sink output.Sink
 }
 
-func NewTerraform(product *api.Product, versionName string, startTime time.Time) Terraform {
+func NewTerraform(product *api.Product, versionName string, startTime time.Time, sink output.Sink) Terraform {
 	t := Terraform{
 		ResourceCount:     0,
 		IAMResourceCount:  0,
@@ -58,6 +66,7 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
 		StartTime:         startTime,
+		Sink:              sink,
 	}
 
 	t.Product.SetPropertiesBasedOnVersion(&t.Version)
@@ -71,13 +80,20 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 }
 
 func (t Terraform) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
 
 	if generateCode {
+		yamlFiles := t.productYamlFiles()
+		if generationCache.Fresh(t.Product.Name, yamlFiles) {
+			log.Printf("Skipping %s product files, unchanged since the last run", t.Product.Name)
+			return
+		}
+		generationCache.Start(t.Product.Name).AddInputs(yamlFiles...)
+
 		t.GenerateProduct(outputFolder)
 		t.GenerateOperation(outputFolder)
 	}
@@ -96,13 +112,44 @@ func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, gen
 	}
 }
 
+// Returns the YAML files of the product and all of its resources. Generating
+// a resource also reads the definitions of other resources, such as the
+// product's resource_defaults and the targets of references, so the cache
+// entries of a product are all invalidated when any of its YAML changes.
+func (t Terraform) productYamlFiles() []string {
+	yamlFiles := slices.Clone(t.Product.YamlFiles)
+	for _, object := range t.Product.Objects {
+		for _, f := range object.YamlFiles {
+			if !slices.Contains(yamlFiles, f) {
+				yamlFiles = append(yamlFiles, f)
+			}
+		}
+	}
+	return yamlFiles
+}
+
 func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) {
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	cacheKey := fmt.Sprintf("%s/%s", t.Product.Name, object.Name)
+	yamlFiles := t.productYamlFiles()
+	if generationCache.Fresh(cacheKey, yamlFiles) {
+		log.Printf("Skipping %s resource, unchanged since the last run", object.Name)
+		return
+	}
+
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.Sink)
+	templateData.cacheEntry = generationCache.Start(cacheKey)
+	templateData.cacheEntry.AddInputs(yamlFiles...)
+	templateData.cacheEntry.AddInputs(customTemplatePaths(&object)...)
+	templateData.cacheEntry.AddInputs(customTemplatePaths(t.Product, "Objects")...)
 
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
 		t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
 
+		if object.GenerateFrameworkResource() && generateCode {
+			t.GenerateFrameworkResource(object, *templateData, outputFolder)
+		}
+
 		if generateCode {
 			// log.Printf("Generating %s tests", object.Name)
 			t.GenerateResourceTests(object, *templateData, outputFolder)
@@ -112,6 +159,28 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 		}
 	}
 
+	if object.GenerateDatasource() {
+		t.GenerateDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GenerateListDatasource() {
+		t.GenerateListDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GenerateListResource() {
+		t.GenerateListResource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GenerateEphemeral() {
+		t.GenerateEphemeralResource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if !object.Exclude {
+		for _, action := range object.Actions {
+			t.GenerateAction(object, *action, *templateData, outputFolder, generateCode, generateDocs)
+		}
+	}
+
 	// if iam_policy is not defined or excluded, don't generate it
 	if object.IamPolicy == nil || object.IamPolicy.Exclude {
 		return
@@ -124,7 +193,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 	if generateCode {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
@@ -133,7 +202,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 
 	if generateDocs {
 		targetFolder := path.Join(outputFolder, "website", "docs", "r")
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
@@ -144,7 +213,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) {
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_meta.yaml", t.FullResourceName(object)))
@@ -167,7 +236,7 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_test.go", t.ResourceGoFilename(object)))
@@ -181,24 +250,163 @@ func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData Te
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_sweeper.go", t.ResourceGoFilename(object)))
 	templateData.GenerateSweeperFile(targetFilePath, object)
 }
 
+// Generates a data source reading a single instance of the resource, along
+// with its documentation and a test reading the resource created by the
+// first test example.
+func (t *Terraform) GenerateDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateDatasourceFile(targetFilePath, object)
+
+		if len(object.TestExamples()) > 0 {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_generated_test.go", t.ResourceGoFilename(object)))
+			templateData.GenerateDatasourceTestFile(targetFilePath, object)
+		}
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+// Generates a data source listing all instances of the resource under a
+// parent, along with its documentation and a test listing the resource
+// created by the first test example.
+func (t *Terraform) GenerateListDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	listName := strings.TrimPrefix(object.ListDatasourceTerraformName(), "google_")
+
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", listName))
+		templateData.GenerateListDatasourceFile(targetFilePath, object)
+
+		if len(object.TestExamples()) > 0 {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_generated_test.go", listName))
+			templateData.GenerateListDatasourceTestFile(targetFilePath, object)
+		}
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", listName))
+		templateData.GenerateListDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+// Generates a plugin framework list resource for the resource, along with its
+// documentation.
+func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("list_resource_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateListResourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "list-resources")
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateListResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+// Generates the plugin framework implementation of a resource, which runs the
+// CRUD functions of the SDK resource generated alongside it. Its
+// documentation is the resource's.
+func (t *Terraform) GenerateFrameworkResource(object api.Resource, templateData TemplateData, outputFolder string) {
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("framework_resource_%s.go", t.ResourceGoFilename(object)))
+	templateData.GenerateFrameworkResourceFile(targetFilePath, object)
+}
+
+func (t *Terraform) GenerateEphemeralResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateEphemeralResourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "ephemeral-resources")
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateEphemeralResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GenerateAction(object api.Resource, action api.Action, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("action_%s_%s.go", t.ResourceGoFilename(object), action.Name))
+		templateData.GenerateActionFile(targetFilePath, action)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "actions")
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s.html.markdown", t.FullResourceName(object), action.Name))
+		templateData.GenerateActionDocumentationFile(targetFilePath, action)
+	}
+}
+
 // GenerateProduct creates the product.go file for a given service directory.
 // This will be used to seed the directory and add a package-level comment
 // specific to the product.
 func (t *Terraform) GenerateProduct(outputFolder string) {
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
 	targetFilePath := path.Join(targetFolder, "product.go")
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.Sink)
+	templateData.cacheEntry = generationCache.Entry(t.Product.Name)
 	templateData.GenerateProductFile(targetFilePath, *t.Product)
 }
 
@@ -212,11 +420,12 @@ func (t *Terraform) GenerateOperation(outputFolder string) {
 	}
 
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_operation.go", google.Underscore(t.Product.Name)))
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.Sink)
+	templateData.cacheEntry = generationCache.Entry(t.Product.Name)
 	templateData.GenerateOperationFile(targetFilePath, *asyncObjects[0])
 }
 
@@ -226,7 +435,7 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 	if generateCode && object.IamPolicy != nil && (object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName)) {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := t.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s.go", t.ResourceGoFilename(object)))
@@ -248,14 +457,14 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 
 func (t *Terraform) GenerateIamDocumentation(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	resourceDocFolder := path.Join(outputFolder, "website", "docs", "r")
-	if err := os.MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourceDocFolder, err))
 	}
 	targetFilePath := path.Join(resourceDocFolder, fmt.Sprintf("%s_iam.html.markdown", t.FullResourceName(object)))
 	templateData.GenerateIamResourceDocumentationFile(targetFilePath, object)
 
 	datasourceDocFolder := path.Join(outputFolder, "website", "docs", "d")
-	if err := os.MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", datasourceDocFolder, err))
 	}
 	targetFilePath = path.Join(datasourceDocFolder, fmt.Sprintf("%s_iam_policy.html.markdown", t.FullResourceName(object)))
@@ -393,12 +602,12 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := t.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
 		// assumption that it was this run that modified it.
-		if info, err := os.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && t.StartTime.Before(info.ModTime()) {
+		if info, err := t.Sink.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && t.StartTime.Before(info.ModTime()) {
 			log.Fatalf("%s was already modified during this run at %s", targetFile, info.ModTime().String())
 		}
 
@@ -414,7 +623,7 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 			permission = 0644
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, permission)
+		err = t.Sink.WriteFile(targetFile, sourceByte, permission)
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
@@ -436,7 +645,7 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 func (t Terraform) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
 	t.generateResourcesForVersion(products)
 	files := t.getCommonCompileFiles(t.TargetVersionName)
-	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.Sink)
 	t.CompileFileList(outputFolder, files, *templateData, products)
 }
 
@@ -502,14 +711,14 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 		Products:  products,
 	}
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := t.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := t.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -521,7 +730,7 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
 		// continue to next file if no file was generated
-		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
+		if _, err := t.Sink.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
 			continue
 		}
 		t.replaceImportPath(outputFolder, target)
@@ -539,7 +748,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 	}
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.Sink.ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add copy file header: %s", targetFile, err)
 	}
@@ -583,7 +792,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.Sink.WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add copy file header: %s", target, err)
 	}
@@ -643,13 +852,13 @@ func (t Terraform) addHashicorpCopyRightHeader(outputFolder, target string) {
 	header := commentBlock(copyrightHeader, lang)
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.Sink.ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add Hashicorp copy right: %s", targetFile, err)
 	}
 
 	sourceByte = google.Concat([]byte(header), sourceByte)
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.Sink.WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add Hashicorp copy right: %s", target, err)
 	}
@@ -671,7 +880,7 @@ func expectedOutputFolder(outputFolder string) bool {
 
 func (t Terraform) replaceImportPath(outputFolder, target string) {
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := t.Sink.ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
 	}
@@ -714,7 +923,7 @@ func (t Terraform) replaceImportPath(outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = t.Sink.WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
 	}
@@ -733,6 +942,26 @@ func (t Terraform) ProviderFromVersion() string {
 	return dir
 }
 
+// Returns the services with generated framework, list or ephemeral resources
+// or actions, which are registered with the plugin framework provider.
+// generateResourcesForVersion must be called first.
+func (t Terraform) GetMmv1ServicesWithFrameworkResources() []string {
+	var names []string
+	for _, object := range t.ResourcesForVersion {
+		names = append(names, object["FrameworkResourceName"], object["ListResourceName"], object["EphemeralName"])
+	}
+	names = append(names, t.ActionsForVersion...)
+
+	var services []string
+	for _, name := range names {
+		service, _, found := strings.Cut(name, ".")
+		if found && !slices.Contains(services, service) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
 // Gets the list of services dependent on the version ga, beta, and private
 // If there are some resources of a servcie is in GA,
 // then this service is in GA. Otherwise, the service is in BETA
@@ -779,13 +1008,40 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
-			var resourceName string
+			var resourceName, frameworkResourceName string
 
-			if !object.IsExcluded() {
+			if object.GenerateFrameworkResource() {
+				t.ResourceCount++
+				frameworkResourceName = fmt.Sprintf("%s.%s", service, object.FrameworkResourceName())
+			} else if !object.IsExcluded() {
 				t.ResourceCount++
 				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
 			}
 
+			var datasourceName string
+			if object.GenerateDatasource() {
+				datasourceName = fmt.Sprintf("%s.%s", service, object.DatasourceName())
+			}
+
+			var listDatasourceName string
+			if object.GenerateListDatasource() {
+				listDatasourceName = fmt.Sprintf("%s.%s", service, object.ListDatasourceName())
+			}
+
+			var listResourceName string
+			if object.GenerateListResource() {
+				listResourceName = fmt.Sprintf("%s.%s", service, object.ListResourceName())
+			}
+
+			var ephemeralName string
+			if object.GenerateEphemeral() {
+				ephemeralName = fmt.Sprintf("%s.%s", service, object.EphemeralName())
+			}
+
+			for _, action := range object.Actions {
+				t.ActionsForVersion = append(t.ActionsForVersion, fmt.Sprintf("%s.%s", service, action.FunctionName()))
+			}
+
 			var iamClassName string
 			iamPolicy := object.IamPolicy
 			if iamPolicy != nil && !iamPolicy.Exclude {
@@ -797,9 +1053,15 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 			}
 
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":               object.TerraformName(),
+				"ResourceName":                resourceName,
+				"FrameworkResourceName":       frameworkResourceName,
+				"DatasourceName":              datasourceName,
+				"ListDatasourceTerraformName": object.ListDatasourceTerraformName(),
+				"ListDatasourceName":          listDatasourceName,
+				"ListResourceName":            listResourceName,
+				"EphemeralName":               ephemeralName,
+				"IamClassName":                iamClassName,
 			})
 		}
 	}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 )
 
 type TerraformOiCS struct {
@@ -34,14 +35,18 @@ type TerraformOiCS struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Receives the generated files
+	Sink output.Sink
 }
 
-func NewTerraformOiCS(product *api.Product, versionName string, startTime time.Time) TerraformOiCS {
+func NewTerraformOiCS(product *api.Product, versionName string, startTime time.Time, sink output.Sink) TerraformOiCS {
 	toics := TerraformOiCS{
 		Product:           product,
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
 		StartTime:         startTime,
+		Sink:              sink,
 	}
 
 	toics.Product.SetPropertiesBasedOnVersion(&toics.Version)
@@ -67,7 +72,7 @@ func (toics TerraformOiCS) GenerateObjects(outputFolder, resourceToGenerate stri
 }
 
 func (toics TerraformOiCS) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	templateData := NewTemplateData(outputFolder, toics.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, toics.TargetVersionName, toics.Sink)
 
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
@@ -89,7 +94,7 @@ func (toics TerraformOiCS) GenerateResource(object api.Resource, templateData Te
 
 		targetFolder := path.Join(outputFolder, example.Name)
 
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := toics.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating oics example directory %v: %v", targetFolder, err))
 		}
 
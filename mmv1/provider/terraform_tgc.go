@@ -33,6 +33,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 )
 
 type TerraformGoogleConversion struct {
@@ -49,14 +50,18 @@ type TerraformGoogleConversion struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Receives the generated files
+	Sink output.Sink
 }
 
-func NewTerraformGoogleConversion(product *api.Product, versionName string, startTime time.Time) TerraformGoogleConversion {
+func NewTerraformGoogleConversion(product *api.Product, versionName string, startTime time.Time, sink output.Sink) TerraformGoogleConversion {
 	t := TerraformGoogleConversion{
 		Product:           product,
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
 		StartTime:         startTime,
+		Sink:              sink,
 	}
 
 	t.Product.SetPropertiesBasedOnVersion(&t.Version)
@@ -73,7 +78,7 @@ func (tgc TerraformGoogleConversion) Generate(outputFolder, productPath, resourc
 	// Temporary shim to generate the missing resources directory. Can be removed
 	// once the folder exists downstream.
 	resourcesFolder := path.Join(outputFolder, "converters/google/resources")
-	if err := os.MkdirAll(resourcesFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(resourcesFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourcesFolder, err))
 	}
 	tgc.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
@@ -98,7 +103,7 @@ func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputF
 		return
 	}
 
-	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName, tgc.Sink)
 
 	if !object.IsExcluded() {
 		tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
@@ -120,7 +125,7 @@ func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputF
 func (tgc TerraformGoogleConversion) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "converters/google/resources/services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
@@ -139,7 +144,7 @@ func (tgc TerraformGoogleConversion) GenerateIamPolicy(object api.Resource, temp
 
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "converters/google/resources/services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
@@ -200,7 +205,7 @@ func (tgc TerraformGoogleConversion) CompileCommonFiles(outputFolder string, pro
 		testSource[target] = source
 	}
 
-	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName, tgc.Sink)
 	tgc.CompileFileList(outputFolder, testSource, *templateData, products)
 
 	resourceConverters := map[string]string{
@@ -215,14 +220,14 @@ func (tgc TerraformGoogleConversion) CompileCommonFiles(outputFolder string, pro
 }
 
 func (tgc TerraformGoogleConversion) CompileFileList(outputFolder string, files map[string]string, fileTemplate TemplateData, products []*api.Product) {
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := tgc.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -442,12 +447,12 @@ func (tgc TerraformGoogleConversion) CopyFileList(outputFolder string, files map
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := tgc.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
 		// assumption that it was this run that modified it.
-		if info, err := os.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && tgc.StartTime.Before(info.ModTime()) {
+		if info, err := tgc.Sink.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && tgc.StartTime.Before(info.ModTime()) {
 			log.Fatalf("%s was already modified during this run at %s", targetFile, info.ModTime().String())
 		}
 
@@ -456,7 +461,7 @@ func (tgc TerraformGoogleConversion) CopyFileList(outputFolder string, files map
 			log.Fatalf("Cannot read source file %s while copying: %s", source, err)
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, 0644)
+		err = tgc.Sink.WriteFile(targetFile, sourceByte, 0644)
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
@@ -471,7 +476,7 @@ func (tgc TerraformGoogleConversion) CopyFileList(outputFolder string, files map
 func (tgc TerraformGoogleConversion) replaceImportPath(outputFolder, target string) {
 	// Replace import paths to reference the resources dir instead of the google provider
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := tgc.Sink.ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
 	}
@@ -479,7 +484,7 @@ func (tgc TerraformGoogleConversion) replaceImportPath(outputFolder, target stri
 	// replace google to google-beta
 	gaImportPath := ImportPathFromVersion("ga")
 	sourceByte = bytes.Replace(sourceByte, []byte(gaImportPath), []byte(TERRAFORM_PROVIDER_BETA+"/"+RESOURCE_DIRECTORY_BETA), -1)
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = tgc.Sink.WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
 	}
@@ -21,7 +21,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
-	"github.com/otiai10/copy"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 )
 
 // Code generator for a library converting GCP CAI objects to Terraform state.
@@ -33,14 +33,18 @@ type CaiToTerraformConversion struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Receives the generated files
+	Sink output.Sink
 }
 
-func NewCaiToTerraformConversion(product *api.Product, versionName string, startTime time.Time) CaiToTerraformConversion {
+func NewCaiToTerraformConversion(product *api.Product, versionName string, startTime time.Time, sink output.Sink) CaiToTerraformConversion {
 	t := CaiToTerraformConversion{
 		Product:           product,
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
 		StartTime:         startTime,
+		Sink:              sink,
 	}
 
 	t.Product.SetPropertiesBasedOnVersion(&t.Version)
@@ -65,11 +69,11 @@ func (cai2hcl CaiToTerraformConversion) CopyCommonFiles(outputFolder string, gen
 	}
 	log.Print("Copying cai2hcl common files")
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := cai2hcl.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
-	if err := copy.Copy("third_party/cai2hcl", outputFolder); err != nil {
+	if err := output.CopyDirectory(cai2hcl.Sink, "third_party/cai2hcl", outputFolder); err != nil {
 		log.Println(fmt.Errorf("error copying directory %v: %v", outputFolder, err))
 	}
 }
@@ -29,7 +29,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
-	"github.com/otiai10/copy"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/output"
 )
 
 // TerraformGoogleConversionNext is for both tfplan2cai and cai2hcl conversions
@@ -50,6 +50,9 @@ type TerraformGoogleConversionNext struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Receives the generated files
+	Sink output.Sink
 }
 
 type ResourceIdentifier struct {
@@ -59,12 +62,13 @@ type ResourceIdentifier struct {
 	AliasName     string // It can be "Default" or the same with ResourceName
 }
 
-func NewTerraformGoogleConversionNext(product *api.Product, versionName string, startTime time.Time) TerraformGoogleConversionNext {
+func NewTerraformGoogleConversionNext(product *api.Product, versionName string, startTime time.Time, sink output.Sink) TerraformGoogleConversionNext {
 	t := TerraformGoogleConversionNext{
 		Product:                           product,
 		TargetVersionName:                 versionName,
 		Version:                           *product.VersionObjOrClosest(versionName),
 		StartTime:                         startTime,
+		Sink:                              sink,
 		ResourcesGroupedByApiResourceType: make(map[string][]ResourceIdentifier),
 	}
 
@@ -96,7 +100,7 @@ func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, out
 		return
 	}
 
-	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName, tgc.Sink)
 
 	if !object.IsExcluded() {
 		tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
@@ -107,7 +111,7 @@ func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, out
 func (tgc TerraformGoogleConversionNext) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "pkg/services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
@@ -142,7 +146,7 @@ func (tgc *TerraformGoogleConversionNext) GenerateResourceTests(object api.Resou
 
 	productName := tgc.Product.ApiName
 	targetFolder := path.Join(outputFolder, "test", "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s_generated_test.go", productName, google.Underscore(object.Name)))
@@ -172,7 +176,7 @@ func (tgc TerraformGoogleConversionNext) CompileCommonFiles(outputFolder string,
 		"pkg/cai2hcl/converters/resource_converters.go": "templates/tgc_next/cai2hcl/resource_converters.go.tmpl",
 	}
 
-	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
+	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName, tgc.Sink)
 	tgc.CompileFileList(outputFolder, resourceConverters, *templateData, products)
 }
 
@@ -183,14 +187,14 @@ func (tgc TerraformGoogleConversionNext) CompileFileList(outputFolder string, fi
 		Products:                      products,
 	}
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := tgc.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -212,11 +216,11 @@ func (tgc TerraformGoogleConversionNext) CopyCommonFiles(outputFolder string, ge
 
 	log.Printf("Copying common files for tgc.")
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := tgc.Sink.MkdirAll(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
-	if err := copy.Copy("third_party/tgc_next", outputFolder); err != nil {
+	if err := output.CopyDirectory(tgc.Sink, "third_party/tgc_next", outputFolder); err != nil {
 		log.Println(fmt.Errorf("error copying directory %v: %v", outputFolder, err))
 	}
 
@@ -265,12 +269,12 @@ func (tgc TerraformGoogleConversionNext) CopyFileList(outputFolder string, files
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := tgc.Sink.MkdirAll(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
 		// assumption that it was this run that modified it.
-		if info, err := os.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && tgc.StartTime.Before(info.ModTime()) {
+		if info, err := tgc.Sink.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && tgc.StartTime.Before(info.ModTime()) {
 			log.Fatalf("%s was already modified during this run at %s", targetFile, info.ModTime().String())
 		}
 
@@ -279,7 +283,7 @@ func (tgc TerraformGoogleConversionNext) CopyFileList(outputFolder string, files
 			log.Fatalf("Cannot read source file %s while copying: %s", source, err)
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, 0644)
+		err = tgc.Sink.WriteFile(targetFile, sourceByte, 0644)
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
@@ -294,7 +298,7 @@ func (tgc TerraformGoogleConversionNext) CopyFileList(outputFolder string, files
 func (tgc TerraformGoogleConversionNext) replaceImportPath(outputFolder, target string) {
 	// Replace import paths to reference the resources dir instead of the google provider
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := tgc.Sink.ReadFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
 	}
@@ -304,7 +308,7 @@ func (tgc TerraformGoogleConversionNext) replaceImportPath(outputFolder, target
 	sourceByte = bytes.Replace(sourceByte, []byte(gaImportPath), []byte(TGC_PROVIDER+"/"+RESOURCE_DIRECTORY_TGC), -1)
 	sourceByte = bytes.Replace(sourceByte, []byte(TERRAFORM_PROVIDER_GA+"/version"), []byte(TGC_PROVIDER+"/"+RESOURCE_DIRECTORY_TGC+"/version"), -1)
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = tgc.Sink.WriteFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
 	}
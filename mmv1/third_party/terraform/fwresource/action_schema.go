@@ -0,0 +1,200 @@
+package fwresource
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ActionSchemaFromResourceSchema converts the schema of an SDK resource to
+// the schema of an action, so that the configuration of actions can be read
+// into the ResourceData of the SDK resource with SetResourceData. Actions
+// have no state, so computed fields are left out and fields that are both
+// optional and computed become optional. Nested resources become nested
+// attributes, which have the same type as the blocks the SDK uses for them.
+func ActionSchemaFromResourceSchema(rs map[string]*sdk_schema.Schema) schema.Schema {
+	return schema.Schema{
+		Attributes: actionAttributes(rs),
+	}
+}
+
+func actionAttributes(rs map[string]*sdk_schema.Schema) map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(rs))
+	for k, v := range rs {
+		if v.Required || v.Optional {
+			attributes[k] = actionAttribute(v)
+		}
+	}
+	return attributes
+}
+
+func actionAttribute(s *sdk_schema.Schema) schema.Attribute {
+	switch s.Type {
+	case sdk_schema.TypeBool:
+		return schema.BoolAttribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeInt:
+		return schema.Int64Attribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeFloat:
+		return schema.Float64Attribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeMap:
+		return schema.MapAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeList:
+		if r, ok := s.Elem.(*sdk_schema.Resource); ok {
+			return schema.ListNestedAttribute{
+				Description: s.Description,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: actionAttributes(r.SchemaMap()),
+				},
+				Required:           s.Required,
+				Optional:           s.Optional,
+				DeprecationMessage: s.Deprecated,
+			}
+		}
+		return schema.ListAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeSet:
+		if r, ok := s.Elem.(*sdk_schema.Resource); ok {
+			return schema.SetNestedAttribute{
+				Description: s.Description,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: actionAttributes(r.SchemaMap()),
+				},
+				Required:           s.Required,
+				Optional:           s.Optional,
+				DeprecationMessage: s.Deprecated,
+			}
+		}
+		return schema.SetAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	default:
+		return schema.StringAttribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			DeprecationMessage: s.Deprecated,
+		}
+	}
+}
+
+// SetResourceData sets the fields of d to the values of an action's
+// configuration, whose schema was converted from d's schema with
+// ActionSchemaFromResourceSchema. Null values are left unset.
+func SetResourceData(d *sdk_schema.ResourceData, config tftypes.Value) error {
+	var values map[string]tftypes.Value
+	if err := config.As(&values); err != nil {
+		return err
+	}
+	for k, v := range values {
+		if v.IsNull() {
+			continue
+		}
+		raw, err := sdkValue(v)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", k, err)
+		}
+		if err := d.Set(k, raw); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// sdkValue returns a value in the form the SDK accepts when setting fields,
+// with lists and sets as slices and objects and maps as maps.
+func sdkValue(v tftypes.Value) (interface{}, error) {
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	ty := v.Type()
+	switch {
+	case ty.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case ty.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case ty.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		if i, accuracy := n.Int64(); accuracy == big.Exact {
+			return int(i), nil
+		}
+		f, _ := n.Float64()
+		return f, nil
+	case ty.Is(tftypes.List{}), ty.Is(tftypes.Set{}), ty.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			raw, err := sdkValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, raw)
+		}
+		return result, nil
+	case ty.Is(tftypes.Map{}), ty.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(attrs))
+		for k, attr := range attrs {
+			if attr.IsNull() {
+				continue
+			}
+			raw, err := sdkValue(attr)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = raw
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", ty)
+	}
+}
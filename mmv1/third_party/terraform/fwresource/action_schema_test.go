@@ -0,0 +1,161 @@
+package fwresource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestActionSchemaFromResourceSchema(t *testing.T) {
+	rs := map[string]*sdk_schema.Schema{
+		"name": {
+			Type:     sdk_schema.TypeString,
+			Required: true,
+		},
+		"project": {
+			Type:     sdk_schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"state": {
+			Type:     sdk_schema.TypeString,
+			Computed: true,
+		},
+		"config": {
+			Type:     sdk_schema.TypeList,
+			Optional: true,
+			Elem: &sdk_schema.Resource{
+				Schema: map[string]*sdk_schema.Schema{
+					"size": {
+						Type:     sdk_schema.TypeInt,
+						Optional: true,
+					},
+					"zones": {
+						Type:     sdk_schema.TypeSet,
+						Optional: true,
+						Elem:     &sdk_schema.Schema{Type: sdk_schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	s := ActionSchemaFromResourceSchema(rs)
+	if diags := s.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+	for _, k := range []string{"name", "project", "config"} {
+		if _, ok := s.Attributes[k]; !ok {
+			t.Errorf("bad: %s, expected attribute to be present", k)
+		}
+	}
+	if _, ok := s.Attributes["state"]; ok {
+		t.Errorf("bad: state, expected computed attribute to be left out")
+	}
+}
+
+func TestSetResourceData(t *testing.T) {
+	rs := map[string]*sdk_schema.Schema{
+		"name": {
+			Type:     sdk_schema.TypeString,
+			Required: true,
+		},
+		"project": {
+			Type:     sdk_schema.TypeString,
+			Optional: true,
+		},
+		"ratio": {
+			Type:     sdk_schema.TypeFloat,
+			Optional: true,
+		},
+		"labels": {
+			Type:     sdk_schema.TypeMap,
+			Optional: true,
+			Elem:     &sdk_schema.Schema{Type: sdk_schema.TypeString},
+		},
+		"config": {
+			Type:     sdk_schema.TypeList,
+			Optional: true,
+			Elem: &sdk_schema.Resource{
+				Schema: map[string]*sdk_schema.Schema{
+					"size": {
+						Type:     sdk_schema.TypeInt,
+						Optional: true,
+					},
+					"enabled": {
+						Type:     sdk_schema.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ty := ActionSchemaFromResourceSchema(rs).Type().TerraformType(ctx)
+	configType := ty.(tftypes.Object).AttributeTypes["config"]
+	objectType := configType.(tftypes.List).ElementType
+	config := tftypes.NewValue(ty, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, "my-instance"),
+		"project": tftypes.NewValue(tftypes.String, nil),
+		"ratio":   tftypes.NewValue(tftypes.Number, 0.5),
+		"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		"config": tftypes.NewValue(configType, []tftypes.Value{
+			tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"size":    tftypes.NewValue(tftypes.Number, 3),
+				"enabled": tftypes.NewValue(tftypes.Bool, nil),
+			}),
+		}),
+	})
+
+	d := (&sdk_schema.Resource{Schema: rs}).Data(nil)
+	if err := SetResourceData(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"name":    "my-instance",
+		"project": "",
+		"ratio":   0.5,
+		"labels":  map[string]interface{}{"env": "prod"},
+		"config": []interface{}{
+			map[string]interface{}{
+				"size":    3,
+				"enabled": false,
+			},
+		},
+	}
+	for k, want := range expected {
+		if got := d.Get(k); !reflect.DeepEqual(got, want) {
+			t.Errorf("bad: %s, expected %#v, got %#v", k, want, got)
+		}
+	}
+	if _, ok := d.GetOk("project"); ok {
+		t.Errorf("bad: project, expected null value to be left unset")
+	}
+}
+
+func TestSetResourceData_unknown(t *testing.T) {
+	rs := map[string]*sdk_schema.Schema{
+		"name": {
+			Type:     sdk_schema.TypeString,
+			Required: true,
+		},
+	}
+
+	ty := ActionSchemaFromResourceSchema(rs).Type().TerraformType(context.Background())
+	config := tftypes.NewValue(ty, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	d := (&sdk_schema.Resource{Schema: rs}).Data(nil)
+	if err := SetResourceData(d, config); err == nil {
+		t.Errorf("expected an error for an unknown value")
+	}
+}
@@ -0,0 +1,156 @@
+package fwresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// EphemeralSchemaFromResourceSchema converts the schema of an SDK resource to
+// the schema of an ephemeral resource, so that ephemeral resources can be
+// populated through the ResourceData of the SDK resource. Nested resources
+// become nested attributes, which have the same type as the blocks the SDK
+// uses for them, and the SDK's implicit id is added as a computed attribute.
+func EphemeralSchemaFromResourceSchema(rs map[string]*sdk_schema.Schema) schema.Schema {
+	attributes := ephemeralAttributes(rs)
+	if _, ok := attributes["id"]; !ok {
+		attributes["id"] = schema.StringAttribute{
+			Computed: true,
+		}
+	}
+	return schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func ephemeralAttributes(rs map[string]*sdk_schema.Schema) map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(rs))
+	for k, v := range rs {
+		attributes[k] = ephemeralAttribute(v)
+	}
+	return attributes
+}
+
+func ephemeralAttribute(s *sdk_schema.Schema) schema.Attribute {
+	switch s.Type {
+	case sdk_schema.TypeBool:
+		return schema.BoolAttribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeInt:
+		return schema.Int64Attribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeFloat:
+		return schema.Float64Attribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeMap:
+		return schema.MapAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeList:
+		if r, ok := s.Elem.(*sdk_schema.Resource); ok {
+			return schema.ListNestedAttribute{
+				Description: s.Description,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ephemeralAttributes(r.SchemaMap()),
+				},
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Computed:           s.Computed,
+				Sensitive:          s.Sensitive,
+				DeprecationMessage: s.Deprecated,
+			}
+		}
+		return schema.ListAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	case sdk_schema.TypeSet:
+		if r, ok := s.Elem.(*sdk_schema.Resource); ok {
+			return schema.SetNestedAttribute{
+				Description: s.Description,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ephemeralAttributes(r.SchemaMap()),
+				},
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Computed:           s.Computed,
+				Sensitive:          s.Sensitive,
+				DeprecationMessage: s.Deprecated,
+			}
+		}
+		return schema.SetAttribute{
+			Description:        s.Description,
+			ElementType:        ephemeralElementType(s.Elem),
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	default:
+		return schema.StringAttribute{
+			Description:        s.Description,
+			Required:           s.Required,
+			Optional:           s.Optional,
+			Computed:           s.Computed,
+			Sensitive:          s.Sensitive,
+			DeprecationMessage: s.Deprecated,
+		}
+	}
+}
+
+// ephemeralElementType returns the type of the elements of a collection,
+// which the SDK defaults to strings.
+func ephemeralElementType(elem interface{}) attr.Type {
+	s, ok := elem.(*sdk_schema.Schema)
+	if !ok {
+		return types.StringType
+	}
+
+	switch s.Type {
+	case sdk_schema.TypeBool:
+		return types.BoolType
+	case sdk_schema.TypeInt:
+		return types.Int64Type
+	case sdk_schema.TypeFloat:
+		return types.Float64Type
+	case sdk_schema.TypeMap:
+		return types.MapType{ElemType: ephemeralElementType(s.Elem)}
+	case sdk_schema.TypeList:
+		return types.ListType{ElemType: ephemeralElementType(s.Elem)}
+	case sdk_schema.TypeSet:
+		return types.SetType{ElemType: ephemeralElementType(s.Elem)}
+	default:
+		return types.StringType
+	}
+}
@@ -0,0 +1,96 @@
+package fwresource
+
+import (
+	"context"
+	"testing"
+
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEphemeralSchemaFromResourceSchema(t *testing.T) {
+	cases := map[string]map[string]*sdk_schema.Schema{
+		"primitive fields": {
+			"name": {
+				Type:     sdk_schema.TypeString,
+				Required: true,
+			},
+			"size": {
+				Type:     sdk_schema.TypeInt,
+				Computed: true,
+			},
+			"ratio": {
+				Type:     sdk_schema.TypeFloat,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     sdk_schema.TypeBool,
+				Computed: true,
+			},
+		},
+		"collections": {
+			"labels": {
+				Type:     sdk_schema.TypeMap,
+				Computed: true,
+				Elem:     &sdk_schema.Schema{Type: sdk_schema.TypeString},
+			},
+			"zones": {
+				Type:     sdk_schema.TypeList,
+				Computed: true,
+				Elem:     &sdk_schema.Schema{Type: sdk_schema.TypeString},
+			},
+			"ports": {
+				Type:     sdk_schema.TypeSet,
+				Computed: true,
+				Elem:     &sdk_schema.Schema{Type: sdk_schema.TypeInt},
+			},
+		},
+		"nested resources": {
+			"config": {
+				Type:     sdk_schema.TypeList,
+				Computed: true,
+				Elem: &sdk_schema.Resource{
+					Schema: map[string]*sdk_schema.Schema{
+						"key": {
+							Type:      sdk_schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"rules": {
+							Type:     sdk_schema.TypeSet,
+							Computed: true,
+							Elem: &sdk_schema.Resource{
+								Schema: map[string]*sdk_schema.Schema{
+									"priority": {
+										Type:     sdk_schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for tn, rs := range cases {
+		ctx := context.Background()
+		s := EphemeralSchemaFromResourceSchema(rs)
+		if diags := s.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("%s failed; invalid schema: %v", tn, diags)
+			continue
+		}
+
+		// Values read into the SDK resource must convert to the schema's type
+		d := (&sdk_schema.Resource{Schema: rs}).Data(nil)
+		d.SetId("id")
+		state, err := d.TfTypeResourceState()
+		if err != nil {
+			t.Errorf("%s failed; unexpected error: %s", tn, err)
+			continue
+		}
+		if got, want := state.Type(), s.Type().TerraformType(ctx); !got.Equal(want) {
+			t.Errorf("%s failed; expected type %s to be %s", tn, got, want)
+		}
+	}
+}
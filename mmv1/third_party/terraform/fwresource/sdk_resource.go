@@ -0,0 +1,489 @@
+package fwresource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The key of the private state the SDK's private data, such as timeouts, is
+// stored under.
+const sdkPrivateKey = "sdk"
+
+// SDKResource runs the CRUD functions of an SDK resource for a plugin
+// framework resource whose state has the same type, so that generated
+// resources can be moved to the plugin framework one at a time while keeping
+// their expanders, flatteners and existing state. The framework resource
+// plans changes itself, and the planned values are applied through the SDK's
+// implementation of the plugin protocol. The resource identity, if the SDK
+// resource has one, is set by the SDK resource as well.
+type SDKResource struct {
+	typeName string
+	resource *sdk_schema.Resource
+	server   tfprotov5.ProviderServer
+}
+
+// NewSDKResource returns an SDKResource running the CRUD functions of r with
+// meta, which is usually the provider's *transport_tpg.Config.
+func NewSDKResource(typeName string, r *sdk_schema.Resource, meta interface{}) *SDKResource {
+	p := &sdk_schema.Provider{
+		ResourcesMap: map[string]*sdk_schema.Resource{
+			typeName: r,
+		},
+	}
+	p.SetMeta(meta)
+
+	return &SDKResource{
+		typeName: typeName,
+		resource: r,
+		server:   sdk_schema.NewGRPCProviderServer(p),
+	}
+}
+
+func (r *SDKResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	prior := tftypes.NewValue(req.Plan.Raw.Type(), nil)
+	state, private, identity, diags := r.apply(ctx, prior, req.Plan.Raw, req.Config.Raw, nil, req.Identity)
+	resp.Diagnostics.Append(diags...)
+	if state.IsNull() {
+		return
+	}
+
+	resp.State.Raw = state
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sdkPrivateKey, private)...)
+	resp.Diagnostics.Append(setIdentity(resp.Identity, identity)...)
+}
+
+func (r *SDKResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	private, diags := req.Private.GetKey(ctx, sdkPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := tfprotov5.NewDynamicValue(req.State.Raw.Type(), req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding state", err.Error())
+		return
+	}
+	currentIdentity, err := identityData(req.Identity)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding identity", err.Error())
+		return
+	}
+
+	res, err := r.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:        r.typeName,
+		CurrentState:    &current,
+		CurrentIdentity: currentIdentity,
+		Private:         private,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(sdkDiagnostics(res.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, err := res.NewState.Unmarshal(req.State.Raw.Type())
+	if err != nil {
+		resp.Diagnostics.AddError("Error decoding state", err.Error())
+		return
+	}
+	if state.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state, err = NullZeroValues(req.State.Raw, state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error decoding state", err.Error())
+		return
+	}
+
+	resp.State.Raw = state
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sdkPrivateKey, res.Private)...)
+	resp.Diagnostics.Append(setIdentity(resp.Identity, res.NewIdentity)...)
+}
+
+func (r *SDKResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	private, diags := req.Private.GetKey(ctx, sdkPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, private, identity, diags := r.apply(ctx, req.State.Raw, req.Plan.Raw, req.Config.Raw, private, req.Identity)
+	resp.Diagnostics.Append(diags...)
+	if state.IsNull() {
+		return
+	}
+
+	resp.State.Raw = state
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sdkPrivateKey, private)...)
+	resp.Diagnostics.Append(setIdentity(resp.Identity, identity)...)
+}
+
+func (r *SDKResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	private, diags := req.Private.GetKey(ctx, sdkPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	null := tftypes.NewValue(req.State.Raw.Type(), nil)
+	_, _, _, diags = r.apply(ctx, req.State.Raw, null, null, private, nil)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ImportState imports the resource with the SDK resource's importer, by id or
+// by identity. The imported state is completed by reading the resource
+// afterwards.
+func (r *SDKResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identity, err := identityData(req.Identity)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding identity", err.Error())
+		return
+	}
+
+	res, err := r.server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: r.typeName,
+		ID:       req.ID,
+		Identity: identity,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(sdkDiagnostics(res.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(res.ImportedResources) != 1 {
+		resp.Diagnostics.AddError("Error importing resource", fmt.Sprintf("expected 1 imported resource, got %d", len(res.ImportedResources)))
+		return
+	}
+
+	state, err := res.ImportedResources[0].State.Unmarshal(resp.State.Raw.Type())
+	if err != nil {
+		resp.Diagnostics.AddError("Error decoding state", err.Error())
+		return
+	}
+
+	resp.State.Raw = state
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sdkPrivateKey, res.ImportedResources[0].Private)...)
+	resp.Diagnostics.Append(setIdentity(resp.Identity, res.ImportedResources[0].Identity)...)
+}
+
+// apply applies the planned value of the resource with the SDK resource,
+// destroying it if planned is null. The values the SDK resource returns are
+// only used for the values that were unknown when planning, as the framework
+// requires the values it planned to be kept. The identity the SDK resource
+// sets is returned with the new state.
+func (r *SDKResource) apply(ctx context.Context, prior, planned, config tftypes.Value, private []byte, identity *tfsdk.ResourceIdentity) (tftypes.Value, []byte, *tfprotov5.ResourceIdentityData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ty := planned.Type()
+	null := tftypes.NewValue(ty, nil)
+
+	if !config.IsNull() {
+		var err error
+		private, err = r.timeoutsPrivate(config)
+		if err != nil {
+			diags.AddError("Error decoding timeouts", err.Error())
+			return null, nil, nil, diags
+		}
+	}
+
+	var values [3]tfprotov5.DynamicValue
+	for i, v := range []tftypes.Value{prior, planned, config} {
+		dv, err := tfprotov5.NewDynamicValue(ty, v)
+		if err != nil {
+			diags.AddError("Error encoding plan", err.Error())
+			return null, nil, nil, diags
+		}
+		values[i] = dv
+	}
+
+	plannedIdentity, err := identityData(identity)
+	if err != nil {
+		diags.AddError("Error encoding identity", err.Error())
+		return null, nil, nil, diags
+	}
+
+	res, err := r.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:        r.typeName,
+		PriorState:      &values[0],
+		PlannedState:    &values[1],
+		Config:          &values[2],
+		PlannedPrivate:  private,
+		PlannedIdentity: plannedIdentity,
+	})
+	if err != nil {
+		diags.AddError("Error applying resource", err.Error())
+		return null, nil, nil, diags
+	}
+	diags.Append(sdkDiagnostics(res.Diagnostics)...)
+
+	state, err := res.NewState.Unmarshal(ty)
+	if err != nil {
+		diags.AddError("Error decoding state", err.Error())
+		return null, nil, nil, diags
+	}
+	if state.IsNull() || planned.IsNull() {
+		return state, res.Private, res.NewIdentity, diags
+	}
+
+	state, err = MergeUnknownValues(planned, state)
+	if err != nil {
+		diags.AddError("Error decoding state", err.Error())
+		return null, nil, nil, diags
+	}
+	return state, res.Private, res.NewIdentity, diags
+}
+
+// timeoutsPrivate encodes the timeouts of the configuration the way the SDK
+// stores them in the private data of a planned change.
+func (r *SDKResource) timeoutsPrivate(config tftypes.Value) ([]byte, error) {
+	block := r.resource.CoreConfigSchema()
+
+	dv, err := tfprotov5.NewDynamicValue(config.Type(), config)
+	if err != nil {
+		return nil, err
+	}
+	configVal, err := msgpack.Unmarshal(dv.MsgPack, block.ImpliedType())
+	if err != nil {
+		return nil, err
+	}
+
+	t := &sdk_schema.ResourceTimeout{}
+	if err := t.ConfigDecode(r.resource, terraform.NewResourceConfigShimmed(configVal, block)); err != nil {
+		return nil, err
+	}
+
+	diff := &terraform.InstanceDiff{}
+	if err := t.DiffEncode(diff); err != nil {
+		return nil, err
+	}
+	return json.Marshal(diff.Meta)
+}
+
+// MergeUnknownValues returns the planned value of a resource with the values
+// that were unknown when planning taken from the applied value.
+func MergeUnknownValues(planned, applied tftypes.Value) (tftypes.Value, error) {
+	ty := planned.Type()
+	// Elements of sets are identified by their value, so sets with unknown
+	// values are taken from the applied value as a whole.
+	if !planned.IsKnown() || (ty.Is(tftypes.Set{}) && !planned.IsFullyKnown()) {
+		return applied, nil
+	}
+	if planned.IsNull() || planned.IsFullyKnown() {
+		return planned, nil
+	}
+
+	switch {
+	case ty.Is(tftypes.Object{}), ty.Is(tftypes.Map{}):
+		var p, a map[string]tftypes.Value
+		if err := planned.As(&p); err != nil {
+			return planned, err
+		}
+		if !applied.IsNull() && applied.IsKnown() {
+			if err := applied.As(&a); err != nil {
+				return planned, err
+			}
+		}
+		merged := make(map[string]tftypes.Value, len(p))
+		for k, v := range p {
+			av, ok := a[k]
+			if !ok {
+				av = tftypes.NewValue(v.Type(), nil)
+			}
+			mv, err := MergeUnknownValues(v, av)
+			if err != nil {
+				return planned, err
+			}
+			merged[k] = mv
+		}
+		return tftypes.NewValue(ty, merged), nil
+	case ty.Is(tftypes.List{}), ty.Is(tftypes.Tuple{}):
+		var p, a []tftypes.Value
+		if err := planned.As(&p); err != nil {
+			return planned, err
+		}
+		if !applied.IsNull() && applied.IsKnown() {
+			if err := applied.As(&a); err != nil {
+				return planned, err
+			}
+		}
+		merged := make([]tftypes.Value, len(p))
+		for i, v := range p {
+			av := tftypes.NewValue(v.Type(), nil)
+			if i < len(a) {
+				av = a[i]
+			}
+			mv, err := MergeUnknownValues(v, av)
+			if err != nil {
+				return planned, err
+			}
+			merged[i] = mv
+		}
+		return tftypes.NewValue(ty, merged), nil
+	}
+	return planned, nil
+}
+
+// NullZeroValues returns the value of a resource read by an SDK resource with
+// the values that are null in prior set to null again where the SDK read them
+// as zero values, as the SDK doesn't distinguish between them.
+func NullZeroValues(prior, read tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(read, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if len(p.Steps()) == 0 || !isZeroValue(v) {
+			return v, nil
+		}
+
+		pv, _, err := tftypes.WalkAttributePath(prior, p)
+		if err != nil {
+			return v, nil
+		}
+		if pv, ok := pv.(tftypes.Value); ok && pv.IsNull() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+}
+
+func isZeroValue(v tftypes.Value) bool {
+	if !v.IsKnown() || v.IsNull() {
+		return false
+	}
+
+	ty := v.Type()
+	switch {
+	case ty.Is(tftypes.String):
+		var s string
+		return v.As(&s) == nil && s == ""
+	case ty.Is(tftypes.Bool):
+		var b bool
+		return v.As(&b) == nil && !b
+	case ty.Is(tftypes.Number):
+		var n float64
+		return v.As(&n) == nil && n == 0
+	case ty.Is(tftypes.List{}), ty.Is(tftypes.Set{}):
+		var l []tftypes.Value
+		return v.As(&l) == nil && len(l) == 0
+	case ty.Is(tftypes.Map{}):
+		var m map[string]tftypes.Value
+		return v.As(&m) == nil && len(m) == 0
+	}
+	return false
+}
+
+// DefaultProviderValue plans an attribute that isn't configured, such as the
+// project, with the provider's default for it, as
+// tpgresource.DefaultProviderProject does for SDK resources. The resource is
+// replaced if the planned value changes and requiresReplace is set.
+func DefaultProviderValue(ctx context.Context, name, value string, requiresReplace bool, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, planned, prior types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &config)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IsNull() && value != "" {
+		planned = types.StringValue(value)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), planned)...)
+	}
+
+	// The resource is being created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+	if requiresReplace && !planned.IsUnknown() && !planned.Equal(prior) {
+		resp.RequiresReplace.Append(path.Root(name))
+	}
+}
+
+// SchemaPathExpressions converts the paths of fields used by SDK schema
+// options such as ConflictsWith, like "a.0.b", to framework path expressions.
+func SchemaPathExpressions(paths []string) path.Expressions {
+	var expressions path.Expressions
+	for _, p := range paths {
+		steps := strings.Split(p, ".")
+		expression := path.MatchRoot(steps[0])
+		for _, step := range steps[1:] {
+			if i, err := strconv.Atoi(step); err == nil {
+				expression = expression.AtListIndex(i)
+			} else {
+				expression = expression.AtName(step)
+			}
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions
+}
+
+// identityData encodes an identity for the SDK's protocol server. It is nil
+// if the resource has no identity, or the request doesn't have one yet.
+func identityData(identity *tfsdk.ResourceIdentity) (*tfprotov5.ResourceIdentityData, error) {
+	if identity == nil || identity.Raw.Type() == nil || identity.Raw.IsNull() {
+		return nil, nil
+	}
+
+	dv, err := tfprotov5.NewDynamicValue(identity.Raw.Type(), identity.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.ResourceIdentityData{IdentityData: &dv}, nil
+}
+
+// setIdentity sets the identity of a response to the one returned by the
+// SDK's protocol server, if both the resource and the SDK resource have one.
+func setIdentity(identity *tfsdk.ResourceIdentity, data *tfprotov5.ResourceIdentityData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil || identity.Raw.Type() == nil || data == nil || data.IdentityData == nil {
+		return diags
+	}
+
+	v, err := data.IdentityData.Unmarshal(identity.Raw.Type())
+	if err != nil {
+		diags.AddError("Error decoding identity", err.Error())
+		return diags
+	}
+	identity.Raw = v
+	return diags
+}
+
+// sdkDiagnostics converts the diagnostics of the SDK's protocol server.
+func sdkDiagnostics(in []*tfprotov5.Diagnostic) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, d := range in {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			diags.AddError(d.Summary, d.Detail)
+		} else {
+			diags.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return diags
+}
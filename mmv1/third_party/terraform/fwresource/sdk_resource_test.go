@@ -0,0 +1,599 @@
+package fwresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdk_schema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMergeUnknownValues(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":  tftypes.String,
+		"size":  tftypes.Number,
+		"zones": tftypes.Set{ElementType: tftypes.String},
+	}}
+	applied := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "applied"),
+		"size":  tftypes.NewValue(tftypes.Number, 3),
+		"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "a")}),
+	})
+
+	cases := map[string]struct {
+		Planned  tftypes.Value
+		Expected tftypes.Value
+	}{
+		"known values are kept": {
+			Planned: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "planned"),
+				"size":  tftypes.NewValue(tftypes.Number, nil),
+				"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+			}),
+			Expected: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "planned"),
+				"size":  tftypes.NewValue(tftypes.Number, nil),
+				"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+			}),
+		},
+		"unknown values are applied": {
+			Planned: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "planned"),
+				"size":  tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+				"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			}),
+			Expected: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "planned"),
+				"size":  tftypes.NewValue(tftypes.Number, 3),
+				"zones": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "a")}),
+			}),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			merged, err := MergeUnknownValues(tc.Planned, applied)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !merged.Equal(tc.Expected) {
+				t.Fatalf("incorrect value: got %s, want %s", merged, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestNullZeroValues(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":    tftypes.String,
+		"enabled": tftypes.Bool,
+		"labels":  tftypes.Map{ElementType: tftypes.String},
+	}}
+	read := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, ""),
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+		"labels":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{}),
+	})
+
+	cases := map[string]struct {
+		Prior    tftypes.Value
+		Expected tftypes.Value
+	}{
+		"zero values that were null are null": {
+			Prior: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, nil),
+				"enabled": tftypes.NewValue(tftypes.Bool, nil),
+				"labels":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			}),
+			Expected: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, nil),
+				"enabled": tftypes.NewValue(tftypes.Bool, nil),
+				"labels":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			}),
+		},
+		"zero values that were set are kept": {
+			Prior: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, "foo"),
+				"enabled": tftypes.NewValue(tftypes.Bool, false),
+				"labels":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			}),
+			Expected: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, ""),
+				"enabled": tftypes.NewValue(tftypes.Bool, false),
+				"labels":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			}),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			normalized, err := NullZeroValues(tc.Prior, read)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !normalized.Equal(tc.Expected) {
+				t.Fatalf("incorrect value: got %s, want %s", normalized, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestSchemaPathExpressions(t *testing.T) {
+	cases := map[string]struct {
+		Paths    []string
+		Expected path.Expressions
+	}{
+		"top-level field": {
+			Paths:    []string{"name"},
+			Expected: path.Expressions{path.MatchRoot("name")},
+		},
+		"nested fields": {
+			Paths: []string{"config.0.name", "config.0.rules.0.action"},
+			Expected: path.Expressions{
+				path.MatchRoot("config").AtListIndex(0).AtName("name"),
+				path.MatchRoot("config").AtListIndex(0).AtName("rules").AtListIndex(0).AtName("action"),
+			},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			expressions := SchemaPathExpressions(tc.Paths)
+			if len(expressions) != len(tc.Expected) {
+				t.Fatalf("incorrect expressions: got %s, want %s", expressions, tc.Expected)
+			}
+			for i := range expressions {
+				if !expressions[i].Equal(tc.Expected[i]) {
+					t.Fatalf("incorrect expression: got %s, want %s", expressions[i], tc.Expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSDKResource runs the CRUD functions of an SDK resource storing widgets
+// in a map through a framework resource schema with the same type.
+func TestSDKResource(t *testing.T) {
+	ctx := context.Background()
+	widgets := make(map[string]string)
+
+	sdkResource := &sdk_schema.Resource{
+		Create: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			d.SetId(d.Get("name").(string))
+			widgets[d.Id()] = d.Get("description").(string)
+			return d.Set("size", 3)
+		},
+		Read: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			description, ok := widgets[d.Id()]
+			if !ok {
+				d.SetId("")
+				return nil
+			}
+			if err := d.Set("name", d.Id()); err != nil {
+				return err
+			}
+			if err := d.Set("size", 3); err != nil {
+				return err
+			}
+			return d.Set("description", description)
+		},
+		Update: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			if !d.HasChange("description") {
+				t.Errorf("expected a change of description")
+			}
+			if d.Timeout(sdk_schema.TimeoutUpdate) != 5*time.Minute {
+				t.Errorf("incorrect update timeout: %s", d.Timeout(sdk_schema.TimeoutUpdate))
+			}
+			widgets[d.Id()] = d.Get("description").(string)
+			return nil
+		},
+		Delete: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			delete(widgets, d.Id())
+			return nil
+		},
+		Importer: &sdk_schema.ResourceImporter{
+			StateContext: sdk_schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &sdk_schema.ResourceTimeout{
+			Create: sdk_schema.DefaultTimeout(20 * time.Minute),
+			Update: sdk_schema.DefaultTimeout(20 * time.Minute),
+			Delete: sdk_schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*sdk_schema.Schema{
+			"name": {
+				Type:     sdk_schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     sdk_schema.TypeString,
+				Optional: true,
+			},
+			"size": {
+				Type:     sdk_schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+	fwSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true},
+			"name":        schema.StringAttribute{Required: true},
+			"description": schema.StringAttribute{Optional: true},
+			"size":        schema.Int64Attribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{Optional: true},
+					"update": schema.StringAttribute{Optional: true},
+					"delete": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+	ty := fwSchema.Type().TerraformType(ctx)
+	timeoutsType := ty.(tftypes.Object).AttributeTypes["timeouts"]
+	value := func(id, description, size, timeouts interface{}) tftypes.Value {
+		var update interface{}
+		if timeouts != nil {
+			update = timeouts
+			timeouts = map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"update": tftypes.NewValue(tftypes.String, update),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}
+		}
+		return tftypes.NewValue(ty, map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, id),
+			"name":        tftypes.NewValue(tftypes.String, "foo"),
+			"description": tftypes.NewValue(tftypes.String, description),
+			"size":        tftypes.NewValue(tftypes.Number, size),
+			"timeouts":    tftypes.NewValue(timeoutsType, timeouts),
+		})
+	}
+
+	server, err := providerserver.NewProtocol5WithError(&testProvider{
+		resource: &testResource{
+			schema: fwSchema,
+			sdk:    NewSDKResource("google_widget", sdkResource, nil),
+		},
+	})()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dynamicValue := func(v tftypes.Value) *tfprotov5.DynamicValue {
+		dv, err := tfprotov5.NewDynamicValue(ty, v)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return &dv
+	}
+	apply := func(prior, planned, config tftypes.Value, private []byte) (tftypes.Value, []byte) {
+		resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+			TypeName:       "google_widget",
+			PriorState:     dynamicValue(prior),
+			PlannedState:   dynamicValue(planned),
+			Config:         dynamicValue(config),
+			PlannedPrivate: private,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+		state, err := resp.NewState.Unmarshal(ty)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return state, resp.Private
+	}
+	read := func(state tftypes.Value, private []byte) (tftypes.Value, []byte) {
+		resp, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+			TypeName:     "google_widget",
+			CurrentState: dynamicValue(state),
+			Private:      private,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+		state, err = resp.NewState.Unmarshal(ty)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return state, resp.Private
+	}
+	null := tftypes.NewValue(ty, nil)
+
+	// Create a widget without a description.
+	state, private := apply(null, value(tftypes.UnknownValue, nil, tftypes.UnknownValue, nil), value(nil, nil, nil, nil), nil)
+	if want := value("foo", nil, 3, nil); !state.Equal(want) {
+		t.Fatalf("incorrect state after create: got %s, want %s", state, want)
+	}
+
+	// Reading it keeps the description null.
+	state, private = read(state, private)
+	if want := value("foo", nil, 3, nil); !state.Equal(want) {
+		t.Fatalf("incorrect state after read: got %s, want %s", state, want)
+	}
+
+	// Update its description with a timeout.
+	state, private = apply(state, value("foo", "bar", 3, "5m"), value(nil, "bar", nil, "5m"), private)
+	if want := value("foo", "bar", 3, "5m"); !state.Equal(want) {
+		t.Fatalf("incorrect state after update: got %s, want %s", state, want)
+	}
+	if widgets["foo"] != "bar" {
+		t.Fatalf("incorrect description after update: %q", widgets["foo"])
+	}
+
+	// Import it.
+	importResp, err := server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: "google_widget",
+		ID:       "foo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range importResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	imported, err := importResp.ImportedResources[0].State.Unmarshal(ty)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if imported, _ = read(imported, importResp.ImportedResources[0].Private); !imported.Equal(value("foo", "bar", 3, nil)) {
+		t.Fatalf("incorrect state after import: %s", imported)
+	}
+
+	// Delete it.
+	if state, _ = apply(state, null, null, private); !state.IsNull() {
+		t.Fatalf("expected a null state after delete, got %s", state)
+	}
+	if _, ok := widgets["foo"]; ok {
+		t.Fatalf("expected the widget to be deleted")
+	}
+
+	// Reading a deleted widget removes it from state.
+	if state, _ = read(value("foo", "bar", 3, nil), nil); !state.IsNull() {
+		t.Fatalf("expected the widget to be removed from state, got %s", state)
+	}
+}
+
+func TestSDKResource_Identity(t *testing.T) {
+	ctx := context.Background()
+
+	sdkResource := &sdk_schema.Resource{
+		Create: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			d.SetId(d.Get("name").(string))
+			return setTestIdentity(d)
+		},
+		Read: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			if err := d.Set("name", d.Id()); err != nil {
+				return err
+			}
+			return setTestIdentity(d)
+		},
+		Delete: func(d *sdk_schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Importer: &sdk_schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *sdk_schema.ResourceData, meta interface{}) ([]*sdk_schema.ResourceData, error) {
+				if d.Id() == "" {
+					identity, err := d.Identity()
+					if err != nil {
+						return nil, err
+					}
+					d.SetId(identity.Get("name").(string))
+				}
+				return []*sdk_schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*sdk_schema.Schema{
+			"name": {
+				Type:     sdk_schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+		Identity: &sdk_schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*sdk_schema.Schema {
+				return map[string]*sdk_schema.Schema{
+					"name": {
+						Type:              sdk_schema.TypeString,
+						RequiredForImport: true,
+					},
+				}
+			},
+		},
+	}
+	fwSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+	ty := fwSchema.Type().TerraformType(ctx)
+	value := func(id interface{}) *tfprotov5.DynamicValue {
+		dv, err := tfprotov5.NewDynamicValue(ty, tftypes.NewValue(ty, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"name": tftypes.NewValue(tftypes.String, "foo"),
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return &dv
+	}
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+	identity := tftypes.NewValue(identityType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "foo"),
+	})
+	checkIdentity := func(data *tfprotov5.ResourceIdentityData) {
+		if data == nil || data.IdentityData == nil {
+			t.Fatalf("expected an identity")
+		}
+		got, err := data.IdentityData.Unmarshal(identityType)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !got.Equal(identity) {
+			t.Fatalf("incorrect identity: got %s, want %s", got, identity)
+		}
+	}
+
+	server, err := providerserver.NewProtocol5WithError(&testProvider{
+		resource: &testIdentityResource{testResource{
+			schema: fwSchema,
+			sdk:    NewSDKResource("google_widget", sdkResource, nil),
+		}},
+	})()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The framework requires the schemas to be read first
+	if _, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Creating a widget sets its identity.
+	createResp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     "google_widget",
+		PriorState:   value(nil),
+		PlannedState: value(tftypes.UnknownValue),
+		Config:       value(nil),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range createResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	checkIdentity(createResp.NewIdentity)
+
+	// Reading a widget without an identity sets it.
+	readResp, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     "google_widget",
+		CurrentState: value("foo"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	checkIdentity(readResp.NewIdentity)
+
+	// Import it by identity.
+	identityData, err := tfprotov5.NewDynamicValue(identityType, identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	importResp, err := server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: "google_widget",
+		Identity: &tfprotov5.ResourceIdentityData{IdentityData: &identityData},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range importResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	imported, err := importResp.ImportedResources[0].State.Unmarshal(ty)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := imported.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := tftypes.NewValue(tftypes.String, "foo"); !attributes["id"].Equal(want) {
+		t.Fatalf("incorrect id after import: got %s, want %s", attributes["id"], want)
+	}
+	checkIdentity(importResp.ImportedResources[0].Identity)
+}
+
+func setTestIdentity(d *sdk_schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	return identity.Set("name", d.Id())
+}
+
+type testProvider struct {
+	resource resource.Resource
+}
+
+func (p *testProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "google"
+}
+
+func (p *testProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p *testProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p *testProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *testProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource { return p.resource },
+	}
+}
+
+type testResource struct {
+	schema schema.Schema
+	sdk    *SDKResource
+}
+
+func (r *testResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "google_widget"
+}
+
+func (r *testResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = r.schema
+}
+
+func (r *testResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.sdk.Create(ctx, req, resp)
+}
+
+func (r *testResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	r.sdk.Read(ctx, req, resp)
+}
+
+func (r *testResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.sdk.Update(ctx, req, resp)
+}
+
+func (r *testResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.sdk.Delete(ctx, req, resp)
+}
+
+func (r *testResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.sdk.ImportState(ctx, req, resp)
+}
+
+type testIdentityResource struct {
+	testResource
+}
+
+func (r *testIdentityResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
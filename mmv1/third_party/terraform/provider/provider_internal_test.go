@@ -1410,6 +1410,50 @@ func TestProvider_ProviderConfigure_requestTimeout(t *testing.T) {
 	}
 }
 
+func TestProvider_ProviderConfigure_pollIntervalScale(t *testing.T) {
+	cases := map[string]struct {
+		ConfigValues  map[string]interface{}
+		ExpectedValue float64
+	}{
+		"poll_interval_scale set in the config is used": {
+			ConfigValues: map[string]interface{}{
+				"poll_interval_scale": 2.5,
+				"credentials":         transport_tpg.TestFakeCredentialsPath,
+			},
+			ExpectedValue: 2.5,
+		},
+		"when poll_interval_scale is unset in the config, the value is 0, which leaves poll intervals unscaled": {
+			ConfigValues: map[string]interface{}{
+				"credentials": transport_tpg.TestFakeCredentialsPath,
+			},
+			ExpectedValue: 0,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+
+			// Arrange
+			ctx := context.Background()
+			acctest.UnsetTestProviderConfigEnvs(t)
+			p := provider.Provider()
+			d := tpgresource.SetupTestResourceDataFromConfigMap(t, p.Schema, tc.ConfigValues)
+
+			// Act
+			c, diags := provider.ProviderConfigure(ctx, d, p)
+
+			// Assert
+			if diags.HasError() {
+				t.Fatalf("unexpected error(s): %#v", diags)
+			}
+			config := c.(*transport_tpg.Config) // Should be non-nil value, as test cases reaching this point experienced no errors
+			if config.PollIntervalScale != tc.ExpectedValue {
+				t.Fatalf("expected poll_interval_scale value in provider struct to be %v, got %v", tc.ExpectedValue, config.PollIntervalScale)
+			}
+		})
+	}
+}
+
 func TestProvider_ProviderConfigure_requestReason(t *testing.T) {
 
 	cases := map[string]struct {
@@ -0,0 +1,32 @@
+package publicca
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+// The API only returns the key as base64, both key fields are flattened from
+// it, as by the ephemeral resource.
+func TestPublicCAExternalAccountKey_FlattenItemMacKeys(t *testing.T) {
+	t.Parallel()
+
+	key := []byte{0xfb, 0xff, 0xfe, 0x01}
+	res := map[string]interface{}{
+		"name":      "projects/my-project/locations/global/externalAccountKeys/my-key",
+		"keyId":     "my-key",
+		"b64MacKey": base64.StdEncoding.EncodeToString(key),
+	}
+
+	d := ResourcePublicCAExternalAccountKey().TestResourceData()
+	item, err := flattenPublicCAExternalAccountKeyItem(res, d, nil)
+	if err != nil {
+		t.Fatalf("Error flattening ExternalAccountKey: %s", err)
+	}
+
+	if got, want := item["b64_mac_key"], base64.StdEncoding.EncodeToString(key); got != want {
+		t.Errorf("expected b64_mac_key to be %q, got %q", want, got)
+	}
+	if got, want := item["b64url_mac_key"], base64.URLEncoding.EncodeToString(key); got != want {
+		t.Errorf("expected b64url_mac_key to be %q, got %q", want, got)
+	}
+}
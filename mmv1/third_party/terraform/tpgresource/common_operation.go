@@ -1,8 +1,11 @@
 package tpgresource
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -137,6 +140,16 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 }
 
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+	return operationWait(w, activity, timeout, pollInterval, nil)
+}
+
+// OperationWaitWithPolling is OperationWait, waiting between polls of the
+// operation as strategy describes.
+func OperationWaitWithPolling(w Waiter, activity string, timeout time.Duration, strategy transport_tpg.PollingStrategy) error {
+	return operationWait(w, activity, timeout, 0, &strategy)
+}
+
+func operationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration, strategy *transport_tpg.PollingStrategy) error {
 	if OperationDone(w) {
 		return w.Error()
 	}
@@ -149,6 +162,9 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 		MinTimeout:   2 * time.Second,
 		PollInterval: pollInterval,
 	}
+	if strategy != nil {
+		strategy.Apply(c)
+	}
 	opRaw, err := c.WaitForState()
 	if err != nil {
 		return fmt.Errorf("Error waiting for %s: %w", activity, err)
@@ -166,3 +182,178 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 // interchangeable API operations. Choose it somewhat arbitrarily to represent
 // the "common" operation.
 type CommonOperation cloudresourcemanager.Operation
+
+// Describes where the fields of a long-running operation are, for APIs whose
+// operations don't have the shape of the common operation. Paths are
+// dot-separated paths of fields in the operation, such as `metadata.state`.
+type OperationDescriptor struct {
+	// The path of the operation's name. Defaults to `name`.
+	NamePath string
+
+	// The path of the flag set when the operation is done. Defaults to `done`,
+	// and isn't used if StatusPath is set.
+	DonePath string
+
+	// The path of the operation's status, which is one of PendingStatuses
+	// while the operation runs and one of TargetStatuses once it's done.
+	StatusPath      string
+	PendingStatuses []string
+	TargetStatuses  []string
+
+	// The path of the error of a failed operation. Defaults to `error`.
+	ErrorPath string
+
+	// The path of the resource in a finished operation. Defaults to
+	// `response`.
+	ResponsePath string
+
+	// The path of the URL the operation is polled at, for operations that
+	// link to themselves.
+	SelfLinkPath string
+
+	// Builds the error of a failed operation from the whole operation, for
+	// APIs that report details outside of the error. Defaults to wrapping
+	// the error in a DescribedOpError.
+	FormatError func(op map[string]interface{}) error
+}
+
+// Wraps the error of a described operation in an implementation of built-in
+// Error.
+type DescribedOpError struct {
+	Value interface{}
+}
+
+func (e *DescribedOpError) Error() string {
+	switch v := e.Value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if msg, ok := v["message"]; ok {
+			if code, ok := v["code"]; ok {
+				return fmt.Sprintf("Error code %v, message: %v", code, msg)
+			}
+			return fmt.Sprintf("%v", msg)
+		}
+		if errs, ok := v["errors"].([]interface{}); ok {
+			var msgs []string
+			for _, err := range errs {
+				msgs = append(msgs, (&DescribedOpError{err}).Error())
+			}
+			return strings.Join(msgs, "\n")
+		}
+	}
+	b, _ := json.Marshal(e.Value)
+	return string(b)
+}
+
+// A Waiter for operations described by an OperationDescriptor. Like
+// CommonOperationWaiter, it's embedded in the waiter of a product, which
+// queries the operation.
+type DescribedOperationWaiter struct {
+	Descriptor OperationDescriptor
+	Op         map[string]interface{}
+}
+
+func (w *DescribedOperationWaiter) State() string {
+	if w == nil || w.Op == nil {
+		return "<nil>"
+	}
+
+	if w.Descriptor.StatusPath != "" {
+		return fmt.Sprintf("%v", operationValue(w.Op, w.Descriptor.StatusPath))
+	}
+	done, _ := operationValue(w.Op, defaultPath(w.Descriptor.DonePath, "done")).(bool)
+	return fmt.Sprintf("done: %v", done)
+}
+
+func (w *DescribedOperationWaiter) Error() error {
+	if w == nil {
+		return nil
+	}
+
+	v := operationValue(w.Op, defaultPath(w.Descriptor.ErrorPath, "error"))
+	if IsEmptyValue(reflect.ValueOf(v)) {
+		return nil
+	}
+	if w.Descriptor.FormatError != nil {
+		return w.Descriptor.FormatError(w.Op)
+	}
+	return &DescribedOpError{v}
+}
+
+func (w *DescribedOperationWaiter) IsRetryable(error) bool {
+	return false
+}
+
+func (w *DescribedOperationWaiter) SetOp(op interface{}) error {
+	m, err := ConvertToMap(op)
+	if err != nil {
+		return err
+	}
+	w.Op = m
+	return nil
+}
+
+func (w *DescribedOperationWaiter) OpName() string {
+	if w == nil {
+		return "<nil>"
+	}
+
+	name, _ := operationValue(w.Op, defaultPath(w.Descriptor.NamePath, "name")).(string)
+	return name
+}
+
+// Returns the URL the operation links to itself with, if it has one.
+func (w *DescribedOperationWaiter) SelfLink() string {
+	if w == nil || w.Descriptor.SelfLinkPath == "" {
+		return ""
+	}
+
+	link, _ := operationValue(w.Op, w.Descriptor.SelfLinkPath).(string)
+	return link
+}
+
+// Returns the resource in a finished operation.
+func (w *DescribedOperationWaiter) Response() (map[string]interface{}, error) {
+	path := defaultPath(w.Descriptor.ResponsePath, "response")
+	v, ok := operationValue(w.Op, path).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("`%s` not set in operation", path)
+	}
+	return v, nil
+}
+
+func (w *DescribedOperationWaiter) PendingStates() []string {
+	if w.Descriptor.StatusPath != "" {
+		return w.Descriptor.PendingStatuses
+	}
+	return []string{"done: false"}
+}
+
+func (w *DescribedOperationWaiter) TargetStates() []string {
+	if w.Descriptor.StatusPath != "" {
+		return w.Descriptor.TargetStatuses
+	}
+	return []string{"done: true"}
+}
+
+func defaultPath(path, def string) string {
+	if path == "" {
+		return def
+	}
+	return path
+}
+
+// Returns the value at a dot-separated path in an operation, or nil if it
+// isn't set.
+func operationValue(op map[string]interface{}, path string) interface{} {
+	var v interface{} = op
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
@@ -1,7 +1,9 @@
 package tpgresource
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,3 +69,184 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+func TestDescribedOperationWaiter(t *testing.T) {
+	cases := map[string]struct {
+		Descriptor    OperationDescriptor
+		Op            map[string]interface{}
+		ExpectedName  string
+		ExpectedState string
+		ExpectedError string
+		ExpectedLink  string
+	}{
+		"common shape": {
+			Op:            map[string]interface{}{"name": "operations/1", "done": false},
+			ExpectedName:  "operations/1",
+			ExpectedState: "done: false",
+		},
+		"common shape with an error": {
+			Op: map[string]interface{}{
+				"name":  "operations/1",
+				"done":  true,
+				"error": map[string]interface{}{"code": 3, "message": "bad request"},
+			},
+			ExpectedName:  "operations/1",
+			ExpectedState: "done: true",
+			ExpectedError: "Error code 3, message: bad request",
+		},
+		"status with a list of errors": {
+			Descriptor: OperationDescriptor{
+				StatusPath:     "status",
+				TargetStatuses: []string{"DONE"},
+				SelfLinkPath:   "selfLink",
+			},
+			Op: map[string]interface{}{
+				"name":     "operation-1",
+				"status":   "DONE",
+				"selfLink": "https://compute.googleapis.com/compute/v1/projects/p/zones/z/operations/operation-1",
+				"error": map[string]interface{}{
+					"errors": []interface{}{
+						map[string]interface{}{"code": "QUOTA_EXCEEDED", "message": "quota exceeded"},
+					},
+				},
+			},
+			ExpectedName:  "operation-1",
+			ExpectedState: "DONE",
+			ExpectedError: "Error code QUOTA_EXCEEDED, message: quota exceeded",
+			ExpectedLink:  "https://compute.googleapis.com/compute/v1/projects/p/zones/z/operations/operation-1",
+		},
+		"nested fields": {
+			Descriptor: OperationDescriptor{
+				NamePath:   "metadata.operationId",
+				StatusPath: "metadata.state",
+				ErrorPath:  "metadata.failureReason",
+			},
+			Op: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"operationId":   "op-1",
+					"state":         "FAILED",
+					"failureReason": "out of capacity",
+				},
+			},
+			ExpectedName:  "op-1",
+			ExpectedState: "FAILED",
+			ExpectedError: "out of capacity",
+		},
+		"formatted error": {
+			Descriptor: OperationDescriptor{
+				StatusPath: "status",
+				FormatError: func(op map[string]interface{}) error {
+					return fmt.Errorf("HTTP error %v: %v", op["httpErrorStatusCode"], op["httpErrorMessage"])
+				},
+			},
+			Op: map[string]interface{}{
+				"name":                "operation-1",
+				"status":              "DONE",
+				"httpErrorStatusCode": 400,
+				"httpErrorMessage":    "BAD REQUEST",
+				"error":               map[string]interface{}{"errors": []interface{}{}},
+			},
+			ExpectedName:  "operation-1",
+			ExpectedState: "DONE",
+			ExpectedError: "HTTP error 400: BAD REQUEST",
+		},
+	}
+
+	for tn, tc := range cases {
+		w := &DescribedOperationWaiter{Descriptor: tc.Descriptor}
+		if err := w.SetOp(tc.Op); err != nil {
+			t.Fatalf("bad: %s, unexpected error %s", tn, err)
+		}
+		if got := w.OpName(); got != tc.ExpectedName {
+			t.Errorf("bad: %s, expected name %q, got %q", tn, tc.ExpectedName, got)
+		}
+		if got := w.State(); got != tc.ExpectedState {
+			t.Errorf("bad: %s, expected state %q, got %q", tn, tc.ExpectedState, got)
+		}
+		if got := w.SelfLink(); got != tc.ExpectedLink {
+			t.Errorf("bad: %s, expected self link %q, got %q", tn, tc.ExpectedLink, got)
+		}
+		var got string
+		if err := w.Error(); err != nil {
+			got = err.Error()
+		}
+		if got != tc.ExpectedError {
+			t.Errorf("bad: %s, expected error %q, got %q", tn, tc.ExpectedError, got)
+		}
+	}
+}
+
+func TestDescribedOperationWaiterResponse(t *testing.T) {
+	w := &DescribedOperationWaiter{Descriptor: OperationDescriptor{ResponsePath: "metadata.target"}}
+	if err := w.SetOp(map[string]interface{}{"metadata": map[string]interface{}{"target": map[string]interface{}{"name": "r"}}}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := w.Response()
+	if err != nil || res["name"] != "r" {
+		t.Errorf("bad: expected the resource in the response, got %v, %v", res, err)
+	}
+
+	w = &DescribedOperationWaiter{}
+	if err := w.SetOp(map[string]interface{}{"done": true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Response(); err == nil || !strings.Contains(err.Error(), "`response`") {
+		t.Errorf("bad: expected an error naming the response field, got %v", err)
+	}
+}
+
+func TestOperationWaitWithPolling(t *testing.T) {
+	testWaiter := TestWaiter{
+		runCount: 0,
+	}
+	strategy := transport_tpg.PollingStrategy{InitialInterval: 100 * time.Millisecond}
+	start := time.Now()
+	err := OperationWaitWithPolling(&testWaiter, "my-activity", 1*time.Minute, strategy)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for operation: got '%v', want 'nil'", err)
+	}
+	expectedRunCount := 2
+	if testWaiter.runCount != expectedRunCount {
+		t.Errorf("expected the retryFunc to be called %v time(s), instead was called %v time(s)",
+			expectedRunCount, testWaiter.runCount)
+	}
+	// The default backoff would have waited at least 2s before the second poll.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected the second poll after the strategy's 100ms interval, took %v", elapsed)
+	}
+}
+
+type TestDescribedWaiter struct {
+	DescribedOperationWaiter
+	ops []map[string]interface{}
+}
+
+func (w *TestDescribedWaiter) QueryOp() (interface{}, error) {
+	op := w.ops[0]
+	w.ops = w.ops[1:]
+	return op, nil
+}
+
+func TestOperationWait_DescribedOperation(t *testing.T) {
+	w := &TestDescribedWaiter{
+		DescribedOperationWaiter: DescribedOperationWaiter{
+			Descriptor: OperationDescriptor{
+				StatusPath:      "status",
+				PendingStatuses: []string{"PENDING", "RUNNING"},
+				TargetStatuses:  []string{"DONE"},
+			},
+		},
+		ops: []map[string]interface{}{
+			{"name": "op", "status": "RUNNING"},
+			{"name": "op", "status": "DONE", "error": map[string]interface{}{"message": "failed"}},
+		},
+	}
+	if err := w.SetOp(map[string]interface{}{"name": "op", "status": "PENDING"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := OperationWait(w, "my-activity", 1*time.Minute, 0*time.Second)
+	if err == nil || err.Error() != "Error waiting for my-activity: failed" {
+		t.Errorf("expected the error of the finished operation, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package tpgresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DeletionProtectionDiff returns a CustomizeDiff function that fails plans
+// replacing an existing resource while the deletion protection field is true
+// in state. forceNewFields are the fields whose changes force the replacement,
+// with the list indexes of nested fields left out, such as "config.size".
+func DeletionProtectionDiff(field string, forceNewFields ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		if old, _ := d.GetChange(field); old != true {
+			return nil
+		}
+		if f := ForceNewFieldChange(d.GetChangedKeysPrefix(""), forceNewFields); f != "" {
+			return fmt.Errorf("cannot replace the resource by changing %s without setting %s=false and running `terraform apply`", f, field)
+		}
+		return nil
+	}
+}
+
+// ForceNewFieldChange returns the first of forceNewFields that one of the
+// changed keys of a diff belongs to, or "" if none of them changed.
+func ForceNewFieldChange(changedKeys []string, forceNewFields []string) string {
+	for _, k := range changedKeys {
+		var parts []string
+		for _, part := range strings.Split(k, ".") {
+			if _, err := strconv.Atoi(part); err == nil || part == "#" || part == "%" {
+				continue
+			}
+			parts = append(parts, part)
+		}
+		path := strings.Join(parts, ".")
+
+		for _, f := range forceNewFields {
+			if path == f || strings.HasPrefix(path, f+".") {
+				return f
+			}
+		}
+	}
+	return ""
+}
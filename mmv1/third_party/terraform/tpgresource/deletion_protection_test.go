@@ -0,0 +1,46 @@
+package tpgresource
+
+import "testing"
+
+func TestForceNewFieldChange(t *testing.T) {
+	forceNewFields := []string{"name", "location", "config.machine_type", "network_config"}
+	cases := map[string]struct {
+		ChangedKeys []string
+		Expected    string
+	}{
+		"no changes": {
+			ChangedKeys: nil,
+			Expected:    "",
+		},
+		"updatable field": {
+			ChangedKeys: []string{"description", "config.0.node_count"},
+			Expected:    "",
+		},
+		"top-level field": {
+			ChangedKeys: []string{"description", "location"},
+			Expected:    "location",
+		},
+		"nested field": {
+			ChangedKeys: []string{"config.0.machine_type"},
+			Expected:    "config.machine_type",
+		},
+		"field nested in a force new field": {
+			ChangedKeys: []string{"network_config.0.subnets.1.cidr"},
+			Expected:    "network_config",
+		},
+		"count of a force new list": {
+			ChangedKeys: []string{"network_config.#"},
+			Expected:    "network_config",
+		},
+		"field sharing a prefix": {
+			ChangedKeys: []string{"name_prefix"},
+			Expected:    "",
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := ForceNewFieldChange(tc.ChangedKeys, forceNewFields); got != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, got)
+		}
+	}
+}
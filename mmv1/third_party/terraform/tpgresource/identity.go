@@ -0,0 +1,28 @@
+package tpgresource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Sets the identity of a resource from the current values of fields, which
+// must be part of both its schema and its identity schema. Identity values are
+// stored as strings, so values of other types are converted.
+func SetResourceIdentity(d *schema.ResourceData, fields ...string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error getting identity: %s", err)
+	}
+
+	for _, field := range fields {
+		var value string
+		if v, ok := d.GetOk(field); ok {
+			value = fmt.Sprint(v)
+		}
+		if err := identity.Set(field, value); err != nil {
+			return fmt.Errorf("Error setting identity field %s: %s", field, err)
+		}
+	}
+	return nil
+}
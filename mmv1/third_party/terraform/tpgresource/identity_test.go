@@ -0,0 +1,69 @@
+package tpgresource_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+)
+
+func TestSetResourceIdentity(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"project": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+					"name": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"number": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+				}
+			},
+		},
+	}
+
+	d := r.Data(nil)
+	d.Set("name", "my-widget")
+	d.Set("number", 42)
+
+	if err := tpgresource.SetResourceIdentity(d, "project", "name", "number"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]string{
+		"project": "",
+		"name":    "my-widget",
+		"number":  "42",
+	}
+	for field, want := range expected {
+		if got := identity.Get(field); got != want {
+			t.Errorf("expected identity field %s to be %q, got %q", field, want, got)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -33,33 +35,8 @@ func ParseImportId(idRegexes []string, d TerraformResourceData, config *transpor
 				fieldName := re.SubexpNames()[i]
 				fieldValue := fieldValues[i]
 				log.Printf("[DEBUG] importing %s = %s", fieldName, fieldValue)
-				// Because we do not know at this point whether 'fieldName'
-				// corresponds to a TypeString or a TypeInteger in the resource
-				// schema, we need to determine the type in an unintuitive way.
-				// We call d.Get, because examining the empty value is the easiest
-				// way to get that out.  Normally, we would be able to just
-				// use a try/catch pattern - try as a string, and if that doesn't
-				// work, try as an integer, and if that doesn't work, return the
-				// error.  Unfortunately, this is not possible here - during tests,
-				// d.Set(...) will panic if there is an error.
-				val, _ := d.GetOk(fieldName)
-				if _, ok := val.(string); val == nil || ok {
-					if err = d.Set(fieldName, fieldValue); err != nil {
-						return err
-					}
-				} else if _, ok := val.(int); ok {
-					if intVal, atoiErr := strconv.Atoi(fieldValue); atoiErr == nil {
-						// If the value can be parsed as an integer, we try to set the
-						// value as an integer.
-						if err = d.Set(fieldName, intVal); err != nil {
-							return err
-						}
-					} else {
-						return fmt.Errorf("%s appears to be an integer, but %v cannot be parsed as an int", fieldName, fieldValue)
-					}
-				} else {
-					return fmt.Errorf(
-						"cannot handle %s, which currently has value %v, and should be set to %#v, during import", fieldName, val, fieldValue)
+				if err := setImportFieldValue(fieldName, fieldValue, d); err != nil {
+					return err
 				}
 			}
 
@@ -75,6 +52,66 @@ func ParseImportId(idRegexes []string, d TerraformResourceData, config *transpor
 	return fmt.Errorf("Import id %q doesn't match any of the accepted formats: %v", d.Id(), idRegexes)
 }
 
+// Parse the identity of a resource imported by identity, setting the fields
+// of the resource from it. Fields left unset in the identity default to the
+// provider configuration like they do when importing by id, using the first
+// of the given regexes, which contains all the fields.
+func ParseImportIdentity(idRegexes []string, d *schema.ResourceData, config *transport_tpg.Config, fields ...string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error getting identity: %s", err)
+	}
+
+	for _, fieldName := range fields {
+		v, ok := identity.GetOk(fieldName)
+		if !ok {
+			continue
+		}
+		fieldValue, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("Identity field %s is a %T, expected a string", fieldName, v)
+		}
+		log.Printf("[DEBUG] importing %s = %s from identity", fieldName, fieldValue)
+		if err := setImportFieldValue(fieldName, fieldValue, d); err != nil {
+			return err
+		}
+	}
+
+	return setDefaultValues(idRegexes[0], d, config)
+}
+
+func setImportFieldValue(fieldName, fieldValue string, d TerraformResourceData) error {
+	// Because we do not know at this point whether 'fieldName'
+	// corresponds to a TypeString or a TypeInteger in the resource
+	// schema, we need to determine the type in an unintuitive way.
+	// We call d.Get, because examining the empty value is the easiest
+	// way to get that out.  Normally, we would be able to just
+	// use a try/catch pattern - try as a string, and if that doesn't
+	// work, try as an integer, and if that doesn't work, return the
+	// error.  Unfortunately, this is not possible here - during tests,
+	// d.Set(...) will panic if there is an error.
+	val, _ := d.GetOk(fieldName)
+	if _, ok := val.(string); val == nil || ok {
+		if err := d.Set(fieldName, fieldValue); err != nil {
+			return err
+		}
+	} else if _, ok := val.(int); ok {
+		if intVal, atoiErr := strconv.Atoi(fieldValue); atoiErr == nil {
+			// If the value can be parsed as an integer, we try to set the
+			// value as an integer.
+			if err := d.Set(fieldName, intVal); err != nil {
+				return err
+			}
+		} else {
+			return fmt.Errorf("%s appears to be an integer, but %v cannot be parsed as an int", fieldName, fieldValue)
+		}
+	} else {
+		return fmt.Errorf(
+			"cannot handle %s, which currently has value %v, and should be set to %#v, during import", fieldName, val, fieldValue)
+	}
+	return nil
+}
+
 func setDefaultValues(idRegex string, d TerraformResourceData, config *transport_tpg.Config) error {
 	if _, ok := d.GetOk("project"); !ok && strings.Contains(idRegex, "?P<project>") {
 		project, err := GetProject(d, config)
@@ -3,6 +3,8 @@ package tpgresource
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -147,3 +149,121 @@ func TestParseImportId(t *testing.T) {
 		}
 	}
 }
+
+func TestParseImportIdentity(t *testing.T) {
+	idRegexes := []string{
+		"projects/(?P<project>[^/]+)/zones/(?P<zone>[^/]+)/instances/(?P<name>[^/]+)",
+		"(?P<project>[^/]+)/(?P<zone>[^/]+)/(?P<name>[^/]+)",
+		"(?P<name>[^/]+)",
+	}
+
+	cases := map[string]struct {
+		Identity             map[string]string
+		Config               *transport_tpg.Config
+		ExpectedSchemaValues map[string]interface{}
+		ExpectError          bool
+	}{
+		"full identity": {
+			Identity: map[string]string{
+				"project": "my-project",
+				"zone":    "my-zone",
+				"name":    "my-instance",
+			},
+			ExpectedSchemaValues: map[string]interface{}{
+				"project": "my-project",
+				"zone":    "my-zone",
+				"name":    "my-instance",
+			},
+		},
+		"identity with default project and zone": {
+			Identity: map[string]string{
+				"name": "my-instance",
+			},
+			Config: &transport_tpg.Config{
+				Project: "default-project",
+				Zone:    "default-zone",
+			},
+			ExpectedSchemaValues: map[string]interface{}{
+				"project": "default-project",
+				"zone":    "default-zone",
+				"name":    "my-instance",
+			},
+		},
+		"provider-level defaults not set": {
+			Identity: map[string]string{
+				"name": "my-instance",
+			},
+			ExpectError: true,
+		},
+	}
+
+	fields := map[string]*schema.Schema{
+		"project": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"zone": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	r := &schema.Resource{
+		Schema: fields,
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"project": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+					"zone": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+					"name": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+				}
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		d := r.Data(nil)
+		identity, err := d.Identity()
+		if err != nil {
+			t.Fatalf("%s failed; unexpected error getting identity: %s", tn, err)
+		}
+		for k, v := range tc.Identity {
+			if err := identity.Set(k, v); err != nil {
+				t.Fatalf("%s failed; unexpected error setting identity field %q: %s", tn, k, err)
+			}
+		}
+		config := tc.Config
+		if config == nil {
+			config = &transport_tpg.Config{}
+		}
+
+		if err := ParseImportIdentity(idRegexes, d, config, "project", "zone", "name"); err == nil {
+			if tc.ExpectError {
+				t.Errorf("%s failed; expected an error", tn)
+			}
+			for k, expectedValue := range tc.ExpectedSchemaValues {
+				if v, ok := d.GetOk(k); ok {
+					if v != expectedValue {
+						t.Errorf("%s failed; Expected value %q for field %q, got %q", tn, expectedValue, k, v)
+					}
+				} else {
+					t.Errorf("%s failed; Expected a value for field %q", tn, k)
+				}
+			}
+		} else if !tc.ExpectError {
+			t.Errorf("%s failed; unexpected error: %s", tn, err)
+		}
+	}
+}
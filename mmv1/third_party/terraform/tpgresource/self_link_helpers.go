@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
@@ -71,6 +72,73 @@ func CompareSelfLinkOrResourceName(_, old, new string, _ *schema.ResourceData) b
 	return CompareSelfLinkRelativePaths("", old, new, nil)
 }
 
+// NormalizeReference converts a reference to another resource, written as its
+// name, relative resource name or self link, to the format sent to the API:
+// "name", "relative_name" or "self_link". relativeName is the relative name of
+// the referenced resource with placeholders for its fields, such as
+// "projects/{{project}}/locations/{{location}}/hubs/{{name}}". A name is
+// completed with the values of the other placeholders in d, and basePath is
+// prepended to references sent as self links. References that don't match
+// relativeName are sent as they're written.
+func NormalizeReference(v interface{}, relativeName, format, basePath string, d TerraformResourceData, config *transport_tpg.Config) (string, error) {
+	ref, _ := v.(string)
+	if ref == "" || format == "name" {
+		return GetResourceNameFromSelfLink(ref), nil
+	}
+
+	var relative string
+	if m := referenceNameRegexp(relativeName).FindStringSubmatch(ref); m != nil {
+		relative = m[1]
+	} else if loc := referencePlaceholderRegexp.FindAllStringIndex(relativeName, -1); len(loc) > 0 && !strings.Contains(ref, "/") {
+		last := loc[len(loc)-1]
+		completed, err := ReplaceVars(d, config, relativeName[:last[0]]+ref+relativeName[last[1]:])
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(completed, "/") || strings.Contains(completed, "//") {
+			return "", fmt.Errorf("cannot complete %q to a relative name like %s", ref, relativeName)
+		}
+		relative = completed
+	} else {
+		return ref, nil
+	}
+
+	if format == "self_link" {
+		return basePath + relative, nil
+	}
+	return relative, nil
+}
+
+var referencePlaceholderRegexp = regexp.MustCompile(`\{\{%?\w+\}\}`)
+
+// Regexps matching the relative names of references, by the relative name
+// format they were built from
+var referenceNameRegexps sync.Map
+
+// Returns a regexp matching relative names like relativeName at the end of a
+// reference, capturing the relative name.
+func referenceNameRegexp(relativeName string) *regexp.Regexp {
+	if re, ok := referenceNameRegexps.Load(relativeName); ok {
+		return re.(*regexp.Regexp)
+	}
+	var pattern strings.Builder
+	last := 0
+	for _, loc := range referencePlaceholderRegexp.FindAllStringIndex(relativeName, -1) {
+		pattern.WriteString(regexp.QuoteMeta(relativeName[last:loc[0]]))
+		// {{%name}} placeholders may contain slashes
+		if relativeName[loc[0]+2] == '%' {
+			pattern.WriteString(".+")
+		} else {
+			pattern.WriteString("[^/]+")
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(relativeName[last:]))
+	re := regexp.MustCompile(`(?:^|/)(` + pattern.String() + `)$`)
+	referenceNameRegexps.Store(relativeName, re)
+	return re
+}
+
 // Hash the relative path of a self link.
 func SelfLinkRelativePathHash(selfLink interface{}) int {
 	path, _ := GetRelativePath(selfLink.(string))
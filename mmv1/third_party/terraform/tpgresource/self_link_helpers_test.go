@@ -1,6 +1,10 @@
 package tpgresource
 
-import "testing"
+import (
+	"testing"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
 
 func TestCompareSelfLinkOrResourceName(t *testing.T) {
 	cases := map[string]struct {
@@ -200,3 +204,82 @@ func TestGetProjectFromRegionalSelfLink(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeReference(t *testing.T) {
+	relativeName := "projects/{{project}}/locations/{{location}}/hubs/{{name}}"
+	d := &ResourceDataMock{
+		FieldsInSchema: map[string]interface{}{
+			"location": "us-central1",
+		},
+	}
+	config := &transport_tpg.Config{Project: "my-project"}
+
+	cases := map[string]struct {
+		Reference string
+		Format    string
+		Expected  string
+	}{
+		"empty": {
+			Reference: "",
+			Format:    "relative_name",
+			Expected:  "",
+		},
+		"name as name": {
+			Reference: "projects/other-project/locations/us-east1/hubs/my-hub",
+			Format:    "name",
+			Expected:  "my-hub",
+		},
+		"name as relative name": {
+			Reference: "my-hub",
+			Format:    "relative_name",
+			Expected:  "projects/my-project/locations/us-central1/hubs/my-hub",
+		},
+		"relative name as relative name": {
+			Reference: "projects/other-project/locations/us-east1/hubs/my-hub",
+			Format:    "relative_name",
+			Expected:  "projects/other-project/locations/us-east1/hubs/my-hub",
+		},
+		"self link as relative name": {
+			Reference: "https://networkconnectivity.googleapis.com/v1/projects/other-project/locations/us-east1/hubs/my-hub",
+			Format:    "relative_name",
+			Expected:  "projects/other-project/locations/us-east1/hubs/my-hub",
+		},
+		"name as self link": {
+			Reference: "my-hub",
+			Format:    "self_link",
+			Expected:  "https://networkconnectivity.googleapis.com/v1/projects/my-project/locations/us-central1/hubs/my-hub",
+		},
+		"unknown format is kept": {
+			Reference: "locations/us-east1/hubs/my-hub",
+			Format:    "relative_name",
+			Expected:  "locations/us-east1/hubs/my-hub",
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := NormalizeReference(tc.Reference, relativeName, tc.Format, "https://networkconnectivity.googleapis.com/v1/", d, config)
+		if err != nil {
+			t.Errorf("bad: %s, unexpected error: %s", tn, err)
+			continue
+		}
+		if got != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, got)
+		}
+	}
+
+	// Names can't be completed without placeholders in the relative name
+	if got, err := NormalizeReference("my-policy", "global/policies/default", "relative_name", "", d, config); err != nil || got != "my-policy" {
+		t.Errorf("bad: expected %q to be kept, got %q, %v", "my-policy", got, err)
+	}
+
+	// {{%name}} placeholders match names with slashes
+	fileName := "projects/{{project}}/locations/{{location}}/repositories/{{repository}}/files/{{%name}}"
+	file := "projects/other-project/locations/us-east1/repositories/my-repo/files/dir/file.txt"
+	if got, err := NormalizeReference("https://artifactregistry.googleapis.com/v1/"+file, fileName, "relative_name", "", d, config); err != nil || got != file {
+		t.Errorf("bad: expected %q, got %q, %v", file, got, err)
+	}
+
+	if _, err := NormalizeReference("my-hub", relativeName, "relative_name", "", &ResourceDataMock{}, config); err == nil {
+		t.Errorf("bad: expected an error completing a name without a location")
+	}
+}
@@ -0,0 +1,70 @@
+package tpgresource
+
+import "strings"
+
+// Moves a value in the raw state of a resource, for state upgraders of
+// fields that were renamed or moved into or out of a nested object. Paths are
+// dot-separated, where the element of a single nested object is addressed
+// with 0 and every element of a list or set with *, such as
+// `settings.0.rules.*.action`. Both paths must share the part up to their
+// last *. Missing and null values aren't moved, and nested objects are
+// created as needed.
+func MoveStateValue(rawState map[string]interface{}, from, to string) {
+	moveStateValue(rawState, strings.Split(from, "."), strings.Split(to, "."))
+}
+
+func moveStateValue(state map[string]interface{}, from, to []string) {
+	if len(from) > 2 && len(to) > 2 && from[0] == to[0] && from[1] == "*" && to[1] == "*" {
+		items, _ := state[from[0]].([]interface{})
+		for _, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				moveStateValue(obj, from[2:], to[2:])
+			}
+		}
+		return
+	}
+
+	v := takeStateValue(state, from)
+	if v == nil {
+		return
+	}
+	putStateValue(state, to, v)
+}
+
+// Removes the value at path from state and returns it, or nil if it isn't set.
+func takeStateValue(state map[string]interface{}, path []string) interface{} {
+	if len(path) == 1 {
+		v := state[path[0]]
+		delete(state, path[0])
+		return v
+	}
+
+	items, _ := state[path[0]].([]interface{})
+	if len(items) == 0 {
+		return nil
+	}
+	obj, ok := items[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return takeStateValue(obj, path[2:])
+}
+
+// Sets the value at path in state, creating the nested objects leading to it.
+func putStateValue(state map[string]interface{}, path []string, v interface{}) {
+	if len(path) == 1 {
+		state[path[0]] = v
+		return
+	}
+
+	items, _ := state[path[0]].([]interface{})
+	if len(items) == 0 || items[0] == nil {
+		items = []interface{}{map[string]interface{}{}}
+		state[path[0]] = items
+	}
+	obj, ok := items[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	putStateValue(obj, path[2:], v)
+}
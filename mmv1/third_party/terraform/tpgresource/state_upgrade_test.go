@@ -0,0 +1,86 @@
+package tpgresource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveStateValue(t *testing.T) {
+	cases := map[string]struct {
+		State    map[string]interface{}
+		From, To string
+		Expect   map[string]interface{}
+	}{
+		"rename": {
+			State:  map[string]interface{}{"old": "a", "other": "b"},
+			From:   "old",
+			To:     "new",
+			Expect: map[string]interface{}{"new": "a", "other": "b"},
+		},
+		"move into a new nested object": {
+			State: map[string]interface{}{"tier": "a"},
+			From:  "tier",
+			To:    "settings.0.tier",
+			Expect: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "a"}},
+			},
+		},
+		"move into an existing nested object": {
+			State: map[string]interface{}{
+				"tier":     "a",
+				"settings": []interface{}{map[string]interface{}{"size": 1}},
+			},
+			From: "tier",
+			To:   "settings.0.tier",
+			Expect: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"size": 1, "tier": "a"}},
+			},
+		},
+		"move out of a nested object": {
+			State: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "a"}},
+			},
+			From: "settings.0.tier",
+			To:   "tier",
+			Expect: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{}},
+				"tier":     "a",
+			},
+		},
+		"rename in every element": {
+			State: map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"old": "a"},
+					map[string]interface{}{"old": "b"},
+				},
+			},
+			From: "rules.*.old",
+			To:   "rules.*.new",
+			Expect: map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"new": "a"},
+					map[string]interface{}{"new": "b"},
+				},
+			},
+		},
+		"null values aren't moved": {
+			State:  map[string]interface{}{"tier": nil},
+			From:   "tier",
+			To:     "settings.0.tier",
+			Expect: map[string]interface{}{},
+		},
+		"missing nested object": {
+			State:  map[string]interface{}{"settings": []interface{}{}},
+			From:   "settings.0.tier",
+			To:     "tier",
+			Expect: map[string]interface{}{"settings": []interface{}{}},
+		},
+	}
+
+	for tn, tc := range cases {
+		MoveStateValue(tc.State, tc.From, tc.To)
+		if !reflect.DeepEqual(tc.State, tc.Expect) {
+			t.Errorf("bad: %s, expected %#v, got %#v", tn, tc.Expect, tc.State)
+		}
+	}
+}
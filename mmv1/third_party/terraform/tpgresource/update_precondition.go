@@ -0,0 +1,15 @@
+package tpgresource
+
+import "fmt"
+
+// ResourceState returns the value of the field at the dot-separated path in a
+// resource read from the API, formatted as a string, or "" if it isn't set.
+// Generated resources compare it with the precondition of an update group to
+// decide whether to update the group.
+func ResourceState(res map[string]interface{}, path string) string {
+	v := operationValue(res, path)
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,44 @@
+package tpgresource
+
+import "testing"
+
+func TestResourceState(t *testing.T) {
+	res := map[string]interface{}{
+		"status": "RUNNING",
+		"metadata": map[string]interface{}{
+			"state":     "ACTIVE",
+			"suspended": false,
+		},
+	}
+	cases := map[string]struct {
+		Path     string
+		Expected string
+	}{
+		"top-level field": {
+			Path:     "status",
+			Expected: "RUNNING",
+		},
+		"nested field": {
+			Path:     "metadata.state",
+			Expected: "ACTIVE",
+		},
+		"boolean field": {
+			Path:     "metadata.suspended",
+			Expected: "false",
+		},
+		"unset field": {
+			Path:     "metadata.phase",
+			Expected: "",
+		},
+		"path through a scalar": {
+			Path:     "status.phase",
+			Expected: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := ResourceState(res, tc.Path); got != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, got)
+		}
+	}
+}
@@ -579,6 +579,15 @@ func MigrateStateNoop(v int, is *terraform.InstanceState, meta interface{}) (*te
 	return is, nil
 }
 
+// Returns the name a renamed field is configured with: its deprecated old
+// name if that's set, and its new name otherwise.
+func RenamedFieldName(d TerraformResourceData, name, oldName string) string {
+	if _, ok := d.GetOkExists(oldName); ok {
+		return oldName
+	}
+	return name
+}
+
 func ExpandString(v interface{}, d TerraformResourceData, config *transport_tpg.Config) (string, error) {
 	return v.(string), nil
 }
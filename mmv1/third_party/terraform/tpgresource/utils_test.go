@@ -1166,3 +1166,36 @@ func TestReplaceVars(t *testing.T) {
 		})
 	}
 }
+
+func TestRenamedFieldName(t *testing.T) {
+	cases := map[string]struct {
+		SchemaValues map[string]interface{}
+		Expected     string
+	}{
+		"configured with the new name": {
+			SchemaValues: map[string]interface{}{"tier": "a"},
+			Expected:     "tier",
+		},
+		"configured with the old name": {
+			SchemaValues: map[string]interface{}{"old_tier": "a"},
+			Expected:     "old_tier",
+		},
+		"old name set to its zero value": {
+			SchemaValues: map[string]interface{}{"old_tier": ""},
+			Expected:     "old_tier",
+		},
+		"unset": {
+			Expected: "tier",
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &tpgresource.ResourceDataMock{
+			FieldsInSchema: tc.SchemaValues,
+		}
+
+		if v := tpgresource.RenamedFieldName(d, "tier", "old_tier"); v != tc.Expected {
+			t.Errorf("bad: %s; expected %q, got %q", tn, tc.Expected, v)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package transport
 import (
 	"fmt"
 	"log"
+	"math"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -48,11 +50,102 @@ func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, ac
 	})
 }
 
+// PollingWaitTimeWithStrategy is PollingWaitTime, waiting between polls as
+// strategy describes instead of backing off from 500ms to 10s.
+func PollingWaitTimeWithStrategy(pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
+	timeout time.Duration, targetOccurrences int, strategy PollingStrategy) error {
+	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
+	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
+	return retryWithTargetOccurrences(timeout, targetOccurrences, func() *retry.RetryError {
+		readResp, readErr := pollF()
+		return checkResponse(readResp, readErr)
+	}, &strategy)
+}
+
+// PollingStrategy describes how long to wait between polls. The first wait
+// is InitialInterval, and each wait after it is the one before multiplied by
+// Multiplier, up to MaxInterval. Each wait is then lengthened or shortened at
+// random by up to Jitter times its length, so that many resources polling at
+// once don't all poll at the same moment.
+type PollingStrategy struct {
+	InitialInterval time.Duration
+	// Zero means the waits aren't limited.
+	MaxInterval time.Duration
+	// Zero means every wait is InitialInterval.
+	Multiplier float64
+	// Between 0 and 1.
+	Jitter float64
+}
+
+// DefaultPollingStrategy is the backoff PollingWaitTime has always used.
+var DefaultPollingStrategy = PollingStrategy{
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+	Multiplier:      2,
+}
+
+// Scale returns the strategy with its intervals multiplied by scale, which
+// comes from the provider's poll_interval_scale. A scale of zero, meaning
+// unset, leaves the strategy as it is.
+func (s PollingStrategy) Scale(scale float64) PollingStrategy {
+	if scale <= 0 {
+		return s
+	}
+	s.InitialInterval = time.Duration(float64(s.InitialInterval) * scale)
+	s.MaxInterval = time.Duration(float64(s.MaxInterval) * scale)
+	return s
+}
+
+// Interval returns the wait before poll n+1, without jitter. Poll 0 happens
+// right away.
+func (s PollingStrategy) Interval(n int) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	interval := float64(s.InitialInterval)
+	if s.Multiplier > 0 {
+		interval *= math.Pow(s.Multiplier, float64(n-1))
+	}
+	if s.MaxInterval > 0 && interval > float64(s.MaxInterval) {
+		return s.MaxInterval
+	}
+	return time.Duration(interval)
+}
+
+// Apply makes c wait between refreshes as the strategy describes, and should
+// be called right before waiting on c. The waits happen inside c.Refresh, so
+// c.PollInterval is set to a millisecond to keep StateChangeConf from adding
+// its own backoff on top, and no wait runs past c.Timeout.
+func (s PollingStrategy) Apply(c *retry.StateChangeConf) {
+	refresh := c.Refresh
+	deadline := time.Now().Add(c.Timeout)
+	n := 0
+	c.Refresh = func() (interface{}, string, error) {
+		wait := s.Interval(n)
+		if s.Jitter > 0 {
+			wait += time.Duration(float64(wait) * s.Jitter * (2*rand.Float64() - 1))
+		}
+		if remaining := time.Until(deadline); wait > remaining {
+			wait = remaining
+		}
+		n++
+		time.Sleep(wait)
+		return refresh()
+	}
+	c.MinTimeout = 0
+	c.PollInterval = time.Millisecond
+}
+
 // RetryWithTargetOccurrences is a basic wrapper around StateChangeConf that will retry
 // a function until it returns the specified amount of target occurrences continuously.
 // Adapted from the Retry function in the go SDK.
 func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 	f retry.RetryFunc) error {
+	return retryWithTargetOccurrences(timeout, targetOccurrences, f, nil)
+}
+
+func retryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
+	f retry.RetryFunc, strategy *PollingStrategy) error {
 	// These are used to pull the error out of the function; need a mutex to
 	// avoid a data race.
 	var resultErr error
@@ -83,6 +176,9 @@ func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 			return nil, "quit", rerr.Err
 		},
 	}
+	if strategy != nil {
+		strategy.Apply(c)
+	}
 
 	_, waitErr := c.WaitForState()
 
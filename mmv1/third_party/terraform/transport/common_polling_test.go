@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollingStrategyInterval(t *testing.T) {
+	cases := map[string]struct {
+		Strategy PollingStrategy
+		Expected []time.Duration
+	}{
+		"fixed interval": {
+			Strategy: PollingStrategy{InitialInterval: 10 * time.Second},
+			Expected: []time.Duration{0, 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		"exponential backoff": {
+			Strategy: PollingStrategy{InitialInterval: time.Second, Multiplier: 2},
+			Expected: []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		"exponential backoff with a max interval": {
+			Strategy: PollingStrategy{InitialInterval: time.Second, MaxInterval: 3 * time.Second, Multiplier: 2},
+			Expected: []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		"fractional multiplier": {
+			Strategy: PollingStrategy{InitialInterval: 2 * time.Second, Multiplier: 1.5},
+			Expected: []time.Duration{0, 2 * time.Second, 3 * time.Second, 4500 * time.Millisecond},
+		},
+		"scaled": {
+			Strategy: PollingStrategy{InitialInterval: time.Second, MaxInterval: 4 * time.Second, Multiplier: 2}.Scale(2),
+			Expected: []time.Duration{0, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second},
+		},
+		"unset scale": {
+			Strategy: PollingStrategy{InitialInterval: time.Second}.Scale(0),
+			Expected: []time.Duration{0, time.Second},
+		},
+	}
+
+	for tn, tc := range cases {
+		for n, expected := range tc.Expected {
+			if got := tc.Strategy.Interval(n); got != expected {
+				t.Errorf("bad: %s, expected interval %d to be %v, got %v", tn, n, expected, got)
+			}
+		}
+	}
+}
+
+func TestPollingWaitTimeWithStrategy(t *testing.T) {
+	polls := 0
+	pollF := func() (map[string]interface{}, error) {
+		polls++
+		return nil, nil
+	}
+	checkResponse := func(_ map[string]interface{}, _ error) PollResult {
+		if polls < 3 {
+			return PendingStatusPollResult("pending")
+		}
+		return SuccessPollResult()
+	}
+	strategy := PollingStrategy{InitialInterval: 50 * time.Millisecond, Multiplier: 2, Jitter: 0.1}
+
+	start := time.Now()
+	if err := PollingWaitTimeWithStrategy(pollF, checkResponse, "polling", time.Minute, 1, strategy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+	// The polls wait about 50ms and then about 100ms.
+	if elapsed := time.Since(start); elapsed < 135*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected the polls to take about 150ms, took %v", elapsed)
+	}
+}
+
+func TestPollingWaitTimeWithStrategy_timeout(t *testing.T) {
+	pollF := func() (map[string]interface{}, error) {
+		return nil, errors.New("not ready")
+	}
+	checkResponse := func(_ map[string]interface{}, err error) PollResult {
+		return PendingStatusPollResult(err.Error())
+	}
+	strategy := PollingStrategy{InitialInterval: time.Hour}
+
+	start := time.Now()
+	err := PollingWaitTimeWithStrategy(pollF, checkResponse, "polling", 200*time.Millisecond, 1, strategy)
+	if err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+	// Waits are cut short at the timeout, rather than running for an hour.
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the wait to end near its timeout, took %v", elapsed)
+	}
+}
@@ -0,0 +1,172 @@
+package transport
+
+import (
+	"context"
+	"iter"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// Options for ListItems. The request options are used for every page, with
+// Method defaulting to GET.
+type ListItemsOptions struct {
+	SendRequestOptions
+
+	// The field of the list response holding the items of a page, such as
+	// "instances". The common "items" field is used if the response doesn't
+	// have it.
+	ItemsField string
+
+	// The maximum number of items per page requested from the API, if
+	// positive. It is sent as PageSizeParam, which defaults to "pageSize"
+	// (AIP-158). Compute APIs use "maxResults".
+	PageSize      int
+	PageSizeParam string
+
+	// An AIP-160 filter expression restricting the listed items, if set.
+	Filter string
+
+	// The maximum number of items yielded, if positive.
+	Limit int
+
+	// The page token of the first page, to continue a listing from a
+	// response that was already read. The first page is requested if empty.
+	PageToken string
+
+	// Stops the listing between pages when done. Defaults to
+	// context.Background().
+	Context context.Context
+}
+
+// Lists the items of a collection, requesting its pages with SendRequest
+// until the response doesn't have a `nextPageToken` (AIP-158). Each item is
+// yielded with a nil error. If a page can't be read, or the context is done,
+// the error is yielded and the listing stops.
+//
+// Items of aggregated lists, where ItemsField holds a map of scopes such as
+// zones, are yielded scope by scope. Items that aren't JSON objects, or are
+// empty, are skipped.
+func ListItems(opt ListItemsOptions) iter.Seq2[map[string]interface{}, error] {
+	return func(yield func(map[string]interface{}, error) bool) {
+		ctx := opt.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		params := map[string]string{}
+		if opt.PageSize > 0 {
+			pageSizeParam := opt.PageSizeParam
+			if pageSizeParam == "" {
+				pageSizeParam = "pageSize"
+			}
+			params[pageSizeParam] = strconv.Itoa(opt.PageSize)
+		}
+		if opt.Filter != "" {
+			params["filter"] = opt.Filter
+		}
+
+		reqOpt := opt.SendRequestOptions
+		if reqOpt.Method == "" {
+			reqOpt.Method = "GET"
+		}
+
+		count := 0
+		for token := opt.PageToken; ; {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if token != "" {
+				params["pageToken"] = token
+			}
+			var err error
+			reqOpt.RawURL, err = AddQueryParams(opt.RawURL, params)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			log.Printf("[DEBUG] Listing %s at %s", opt.ItemsField, reqOpt.RawURL)
+			res, err := SendRequest(reqOpt)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range pageItems(res, opt.ItemsField) {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if opt.Limit > 0 && count >= opt.Limit {
+					return
+				}
+			}
+
+			token, _ = res["nextPageToken"].(string)
+			if token == "" {
+				return
+			}
+		}
+	}
+}
+
+// Returns the items of a page of a list response, flattening the scopes of
+// aggregated lists.
+func pageItems(res map[string]interface{}, itemsField string) []map[string]interface{} {
+	v, ok := res[itemsField]
+	if !ok {
+		v = res["items"]
+	}
+
+	var raw []interface{}
+	switch v := v.(type) {
+	case []interface{}:
+		raw = v
+	case map[string]interface{}:
+		for _, scope := range slices.Sorted(maps.Keys(v)) {
+			scoped, ok := v[scope].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// Scopes either have items or a warning stating there were no
+			// items found in the scope
+			for k, items := range scoped {
+				if k == "warning" {
+					continue
+				}
+				scopeItems, _ := items.([]interface{})
+				raw = append(raw, scopeItems...)
+			}
+		}
+	case nil:
+	default:
+		log.Printf("[WARN] Expected a list or a map of %s, got %T", itemsField, v)
+	}
+
+	var items []map[string]interface{}
+	for _, r := range raw {
+		item, ok := r.(map[string]interface{})
+		if !ok || len(item) < 1 {
+			// Do not include empty json objects coming back from the api
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Lists all items of a collection with ListItems.
+func ListAllItems(opt ListItemsOptions) ([]map[string]interface{}, error) {
+	var items []map[string]interface{}
+	for item, err := range ListItems(opt) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,153 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Serves the widgets 1 to 5, two per page, under the "widgets" field.
+func testListItemsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages := map[string]map[string]interface{}{
+			"": {
+				"widgets":       []interface{}{map[string]interface{}{"name": "w1"}, map[string]interface{}{"name": "w2"}},
+				"nextPageToken": "p2",
+			},
+			"p2": {
+				"widgets":       []interface{}{map[string]interface{}{"name": "w3"}, map[string]interface{}{}, map[string]interface{}{"name": "w4"}},
+				"nextPageToken": "p3",
+			},
+			"p3": {
+				"widgets": []interface{}{map[string]interface{}{"name": "w5"}},
+			},
+		}
+
+		q := r.URL.Query()
+		if got, want := q.Get("filter"), "labels.env=test"; got != want {
+			t.Errorf("expected filter %q to be %q", got, want)
+		}
+		if got, want := q.Get("pageSize"), "2"; got != want {
+			t.Errorf("expected page size %q to be %q", got, want)
+		}
+		page, ok := pages[q.Get("pageToken")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestListItems(t *testing.T) {
+	ts := testListItemsServer(t)
+	defer ts.Close()
+
+	cases := map[string]struct {
+		Limit     int
+		PageToken string
+		Expected  []string
+	}{
+		"all pages": {
+			Expected: []string{"w1", "w2", "w3", "w4", "w5"},
+		},
+		"limit": {
+			Limit:    3,
+			Expected: []string{"w1", "w2", "w3"},
+		},
+		"page token": {
+			PageToken: "p3",
+			Expected:  []string{"w5"},
+		},
+	}
+
+	for tn, tc := range cases {
+		items, err := ListAllItems(ListItemsOptions{
+			SendRequestOptions: SendRequestOptions{
+				Config: &Config{Client: ts.Client()},
+				RawURL: ts.URL + "/v1/widgets",
+			},
+			ItemsField: "widgets",
+			PageSize:   2,
+			Filter:     "labels.env=test",
+			Limit:      tc.Limit,
+			PageToken:  tc.PageToken,
+		})
+		if err != nil {
+			t.Errorf("bad: %s, unexpected error: %s", tn, err)
+			continue
+		}
+
+		var names []string
+		for _, item := range items {
+			names = append(names, item["name"].(string))
+		}
+		if !reflect.DeepEqual(names, tc.Expected) {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, names)
+		}
+	}
+}
+
+func TestListItems_Cancel(t *testing.T) {
+	ts := testListItemsServer(t)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var names []string
+	var lastErr error
+	for item, err := range ListItems(ListItemsOptions{
+		SendRequestOptions: SendRequestOptions{
+			Config: &Config{Client: ts.Client()},
+			RawURL: ts.URL + "/v1/widgets",
+		},
+		ItemsField: "widgets",
+		PageSize:   2,
+		Filter:     "labels.env=test",
+		Context:    ctx,
+	}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		names = append(names, item["name"].(string))
+		// Cancelling stops the listing before the next page
+		cancel()
+	}
+
+	if got, want := names, []string{"w1", "w2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected items %q to be %q", got, want)
+	}
+	if lastErr != context.Canceled {
+		t.Errorf("expected error %v to be %v", lastErr, context.Canceled)
+	}
+}
+
+func TestPageItems_Aggregated(t *testing.T) {
+	res := map[string]interface{}{
+		"items": map[string]interface{}{
+			"zones/us-central1-b": map[string]interface{}{
+				"instances": []interface{}{map[string]interface{}{"name": "i2"}},
+			},
+			"zones/us-central1-a": map[string]interface{}{
+				"instances": []interface{}{map[string]interface{}{"name": "i1"}},
+			},
+			"zones/us-east1-b": map[string]interface{}{
+				"warning": map[string]interface{}{"code": "NO_RESULTS_ON_PAGE"},
+			},
+		},
+	}
+
+	var names []string
+	for _, item := range pageItems(res, "instances") {
+		names = append(names, fmt.Sprint(item["name"]))
+	}
+	if got, want := names, []string{"i1", "i2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected items %q to be %q", got, want)
+	}
+}
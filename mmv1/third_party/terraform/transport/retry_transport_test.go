@@ -291,7 +291,7 @@ func testRetryTransport_checkBody(t *testing.T, resp *http.Response, expectedMsg
 
 	expectedBody := fmt.Sprintf("Request Body: %s", expectedMsg)
 	if !strings.HasSuffix(string(actualBody), expectedBody) {
-		t.Fatalf(expectedBody)
+		t.Fatalf("%s", expectedBody)
 	}
 }
 
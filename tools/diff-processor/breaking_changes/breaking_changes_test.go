@@ -122,6 +122,42 @@ func TestComputeBreakingChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "renaming an optional field with a deprecated alias",
+			oldResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-b": {Description: "beep", Optional: true, ConflictsWith: []string{"field-a"}},
+						"field-a": {Description: "beep", Optional: true, ConflictsWith: []string{"field-b"}, Deprecated: "Use `field-b` instead."},
+					},
+				},
+			},
+		},
+		{
+			name: "renaming a required field with a deprecated alias",
+			oldResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Required: true},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-b": {Description: "beep", Optional: true, ExactlyOneOf: []string{"field-b", "field-a"}},
+						"field-a": {Description: "beep", Optional: true, ExactlyOneOf: []string{"field-b", "field-a"}, Deprecated: "Use `field-b` instead."},
+					},
+				},
+			},
+		},
 		{
 			name: "optional field to required",
 			oldResourceMap: map[string]*schema.Resource{
@@ -17,7 +17,7 @@ package main
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
+	"os"
 	"path"
 	"sort"
 	"text/template"
@@ -120,7 +120,11 @@ func generateResourceWebsiteFile(res *Resource, resources map[Version][]*Resourc
 		fmt.Printf("%v\n", string(source))
 	} else {
 		outname := fmt.Sprintf("%s_%s.html.markdown", res.ProductName(), res.Name())
-		err := ioutil.WriteFile(path.Join(*oPath, "website/docs/r", outname), source, 0644)
+		docsPath := path.Join(*oPath, "website/docs/r")
+		if err := sink.MkdirAll(docsPath, os.ModePerm); err != nil {
+			glog.Error(fmt.Errorf("error creating Terraform docs directory %v: %v", docsPath, err))
+		}
+		err := sink.WriteFile(path.Join(docsPath, outname), source, 0644)
 		if err != nil {
 			glog.Exit(err)
 		}
@@ -9,6 +9,7 @@ require (
 	github.com/hashicorp/hcl v1.0.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/nasa9084/go-openapi v0.0.0-20210722142352-4a81d737faf6
+	github.com/pmezard/go-difflib v1.0.0
 	gopkg.in/yaml.v2 v2.4.0
 )
 
@@ -43,6 +43,11 @@ var vFilter = flag.String("version", "", "optional version name. If specified, t
 
 var mode = flag.String("mode", "", "mode for the generator. If unset, creates the provider. Options: 'serialization'")
 
+var diffOutput = flag.Bool("diff", false, "generate in memory and print a diff against the output path instead of writing to it")
+
+// Where the generated files are written to, replaced with --diff.
+var sink outputSink = fileSystemSink{}
+
 var terraformResourceDirectory = "google-beta"
 var terraformProviderModule = "github.com/hashicorp/terraform-provider-google-beta"
 
@@ -60,6 +65,19 @@ func main() {
 		return
 	}
 
+	var rendered memorySink
+	if *diffOutput {
+		if oPath == nil || *oPath == "" {
+			glog.Exit("--diff requires an output path to diff against")
+		}
+
+		// Render at the paths of the output path without writing to it, to
+		// diff against it once generation is done.
+		rendered = memorySink{}
+		sink = rendered
+		glog.Infof("Generating in memory to diff against %s", *oPath)
+	}
+
 	var resourcesForVersion []*Resource
 	var productsForVersion []*ProductMetadata
 	var version *Version
@@ -115,19 +133,43 @@ func main() {
 
 	if oPath == nil || *oPath == "" {
 		glog.Info("Skipping copying handwritten files, no output specified")
-		return
+	} else if cPath == nil || *cPath == "" {
+		glog.Info("No handwritten path specified")
+	} else {
+		// Copy DCL helper files into the folder tpgdclresource to make it easier to remove these files later.
+		dirPath := path.Join(*oPath, terraformResourceDirectory, "tpgdclresource")
+		if err := sink.MkdirAll(dirPath, os.ModePerm); err != nil {
+			glog.Error(fmt.Errorf("error creating Terraform tpgdclresource directory %v: %v", dirPath, err))
+		}
 	}
 
-	if cPath == nil || *cPath == "" {
-		glog.Info("No handwritten path specified")
-		return
+	if rendered != nil {
+		fullRun := (sFilter == nil || *sFilter == "") && (rFilter == nil || *rFilter == "")
+		if err := printOutputDiff(rendered, *oPath, fullRun); err != nil {
+			glog.Exitf("Error diffing generated output against %s: %v", *oPath, err)
+		}
+	}
+}
+
+// printOutputDiff prints the differences between the existing output and the
+// output rendered by a --diff run. Files that are no longer generated can
+// only be detected when no service or resource filter is set.
+func printOutputDiff(rendered memorySink, outputPath string, fullRun bool) error {
+	diff, err := diffTrees(outputPath, rendered, func(path string, content []byte) bool {
+		return fullRun && bytes.Contains(content, []byte("Type: DCL"))
+	})
+	if err != nil {
+		return err
+	}
+	if !fullRun {
+		glog.Info("Only generating part of the provider, removed files are not reported")
 	}
 
-	// Copy DCL helper files into the folder tpgdclresource to make it easier to remove these files later.
-	dirPath := path.Join(*oPath, terraformResourceDirectory, "tpgdclresource")
-	if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
-		glog.Error(fmt.Errorf("error creating Terraform tpgdclresource directory %v: %v", dirPath, err))
+	if diff.empty() {
+		glog.Infof("No differences found against %s", outputPath)
+		return nil
 	}
+	return diff.write(os.Stdout)
 }
 
 func skipResource(r *Resource) bool {
@@ -338,7 +380,7 @@ func generateSerializationLogic(specs map[Version][]*Resource) {
 	if oPath == nil || *oPath == "" {
 		fmt.Printf("%v", string(formatted))
 	} else {
-		err := ioutil.WriteFile(path.Join(*oPath, "serialization.go"), formatted, 0644)
+		err := sink.WriteFile(path.Join(*oPath, "serialization.go"), formatted, 0644)
 		if err != nil {
 			glog.Exit(err)
 		}
@@ -366,7 +408,7 @@ func loadOverrides(packagePath Filepath, fileName string) Overrides {
 
 func getParentDir(res *Resource) string {
 	servicePath := path.Join(*oPath, terraformResourceDirectory, "services", string(res.Package()))
-	if err := os.MkdirAll(servicePath, os.ModePerm); err != nil {
+	if err := sink.MkdirAll(servicePath, os.ModePerm); err != nil {
 		glog.Error(fmt.Errorf("error creating Terraform the service directory %v: %v", servicePath, err))
 	}
 	return servicePath
@@ -404,7 +446,7 @@ func generateResourceFile(res *Resource) {
 	} else {
 		outname := fmt.Sprintf("resource_%s_%s.go", res.ProductName(), res.Name())
 		parentDir := getParentDir(res)
-		err = ioutil.WriteFile(path.Join(parentDir, outname), formatted, 0644)
+		err = sink.WriteFile(path.Join(parentDir, outname), formatted, 0644)
 		if err != nil {
 			glog.Exit(err)
 		}
@@ -446,7 +488,7 @@ func generateSweeperFile(res *Resource) {
 	} else {
 		outname := fmt.Sprintf("resource_%s_%s_sweeper.go", res.ProductName(), res.Name())
 		parentDir := getParentDir(res)
-		err := ioutil.WriteFile(path.Join(parentDir, outname), formatted, 0644)
+		err := sink.WriteFile(path.Join(parentDir, outname), formatted, 0644)
 		if err != nil {
 			glog.Exit(err)
 		}
@@ -489,7 +531,7 @@ func generateResourceTestFile(res *Resource) {
 	} else {
 		outname := fmt.Sprintf("resource_%s_%s_generated_test.go", res.ProductName(), res.Name())
 		parentDir := getParentDir(res)
-		err = ioutil.WriteFile(path.Join(parentDir, outname), formatted, 0644)
+		err = sink.WriteFile(path.Join(parentDir, outname), formatted, 0644)
 		if err != nil {
 			glog.Exit(err)
 		}
@@ -516,8 +558,14 @@ func generateProviderResourcesFile(resources []*Resource) {
 
 	if oPath == nil || *oPath == "" {
 		fmt.Print(string(formatted))
-	} else if err = ioutil.WriteFile(path.Join(*oPath, terraformResourceDirectory, "provider", "provider_dcl_resources.go"), formatted, 0644); err != nil {
-		glog.Exit(err)
+	} else {
+		providerPath := path.Join(*oPath, terraformResourceDirectory, "provider")
+		if err := sink.MkdirAll(providerPath, os.ModePerm); err != nil {
+			glog.Error(fmt.Errorf("error creating Terraform provider directory %v: %v", providerPath, err))
+		}
+		if err = sink.WriteFile(path.Join(providerPath, "provider_dcl_resources.go"), formatted, 0644); err != nil {
+			glog.Exit(err)
+		}
 	}
 }
 
@@ -550,11 +598,11 @@ func generateProductsFile(fileName string, products []*ProductMetadata) {
 		outname := fileName + ".go"
 
 		DCLFolderPath := path.Join(*oPath, terraformResourceDirectory, "transport")
-		if err := os.MkdirAll(DCLFolderPath, os.ModePerm); err != nil {
+		if err := sink.MkdirAll(DCLFolderPath, os.ModePerm); err != nil {
 			glog.Error(fmt.Errorf("error creating Terraform DCL directory %v: %v", DCLFolderPath, err))
 		}
 
-		if err = ioutil.WriteFile(path.Join(DCLFolderPath, outname), formatted, 0644); err != nil {
+		if err = sink.WriteFile(path.Join(DCLFolderPath, outname), formatted, 0644); err != nil {
 			glog.Exit(err)
 		}
 	}
@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// outputSink receives the generated files: the output path, or memory with
+// --diff.
+type outputSink interface {
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+}
+
+// fileSystemSink writes files straight to the filesystem.
+type fileSystemSink struct{}
+
+func (fileSystemSink) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (fileSystemSink) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// memorySink keeps the generated files in memory, by their cleaned path.
+type memorySink map[string][]byte
+
+func (s memorySink) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	s[filepath.Clean(name)] = append([]byte(nil), data...)
+	return nil
+}
+
+// MkdirAll does nothing, as directories only exist implicitly through the
+// files in them.
+func (s memorySink) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
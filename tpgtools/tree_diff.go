@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// treeDiff holds the differences between an existing output folder and the
+// files rendered for it. Paths are relative to the output folder.
+type treeDiff struct {
+	added    []string
+	removed  []string
+	modified []string
+
+	root     string
+	rendered memorySink
+}
+
+// diffTrees compares the files rendered below root with the files at the
+// same paths on disk. A file that only exists on disk is reported as removed
+// only if its directory was rendered to and isGenerated returns true for it,
+// as the rendering usually only covers part of the existing output.
+func diffTrees(root string, rendered memorySink, isGenerated func(path string, content []byte) bool) (*treeDiff, error) {
+	d := &treeDiff{root: root, rendered: rendered}
+
+	renderedDirs := make(map[string]bool)
+	for p, newContent := range rendered {
+		rel, err := filepath.Rel(root, p)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		renderedDirs[filepath.Dir(rel)] = true
+
+		oldContent, err := os.ReadFile(filepath.Join(root, rel))
+		if errors.Is(err, fs.ErrNotExist) {
+			d.added = append(d.added, rel)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(oldContent, newContent) {
+			d.modified = append(d.modified, rel)
+		}
+	}
+
+	for dir := range renderedDirs {
+		entries, err := os.ReadDir(filepath.Join(root, dir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			rel := filepath.Join(dir, entry.Name())
+			if _, ok := rendered[filepath.Join(root, rel)]; entry.IsDir() || ok {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(root, rel))
+			if err != nil {
+				return nil, err
+			}
+			if isGenerated(rel, content) {
+				d.removed = append(d.removed, rel)
+			}
+		}
+	}
+
+	sort.Strings(d.added)
+	sort.Strings(d.removed)
+	sort.Strings(d.modified)
+	return d, nil
+}
+
+func (d *treeDiff) empty() bool {
+	return len(d.added) == 0 && len(d.removed) == 0 && len(d.modified) == 0
+}
+
+// write writes a summary of the added, removed and modified files, followed
+// by a unified diff of each file in the same order.
+func (d *treeDiff) write(w io.Writer) error {
+	sections := []struct {
+		title string
+		files []string
+	}{
+		{"Added", d.added},
+		{"Removed", d.removed},
+		{"Modified", d.modified},
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(w, "%s files (%d):\n", section.title, len(section.files))
+		for _, f := range section.files {
+			fmt.Fprintf(w, "  %s\n", f)
+		}
+	}
+
+	for _, section := range sections {
+		for _, f := range section.files {
+			if err := d.writeFileDiff(w, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *treeDiff) writeFileDiff(w io.Writer, rel string) error {
+	oldName, newName := filepath.ToSlash(filepath.Join("a", rel)), filepath.ToSlash(filepath.Join("b", rel))
+
+	oldContent, err := os.ReadFile(filepath.Join(d.root, rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		oldName = "/dev/null"
+	} else if err != nil {
+		return err
+	}
+	newContent, ok := d.rendered[filepath.Join(d.root, rel)]
+	if !ok {
+		newName = "/dev/null"
+	}
+
+	if bytes.IndexByte(oldContent, 0) != -1 || bytes.IndexByte(newContent, 0) != -1 {
+		_, err := fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+		return err
+	}
+
+	return difflib.WriteUnifiedDiff(w, difflib.UnifiedDiff{
+		A:        diffLines(oldContent),
+		B:        diffLines(newContent),
+		FromFile: oldName,
+		ToFile:   newName,
+		Context:  3,
+	})
+}
+
+// diffLines splits content into lines that keep their line endings, as expected by
+// difflib. A missing newline at the end of the content is added.
+func diffLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}